@@ -0,0 +1,442 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/BurntSushi/toml"
+	"github.com/alecthomas/kong"
+	"github.com/countdown/countdown/internal/countdown"
+	"gopkg.in/yaml.v3"
+)
+
+// configNames are the file names looked for in the configuration directory,
+// in order of preference.
+var configNames = []string{"config.toml", "config.yaml", "config.yml"}
+
+// configDir returns the directory holding countdown's configuration,
+// $XDG_CONFIG_HOME/countdown or ~/.config/countdown.
+func configDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "countdown")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "countdown")
+}
+
+// sourceFlags are the flags choosing what to count, only one of which can be
+// used. A source given on the command line replaces any in the file, and one
+// in a profile replaces any in the file's defaults.
+var sourceFlags = []string{"range", "duration", "until", "sequence", "agenda"}
+
+// configFile holds settings from a configuration file, keyed by flag name:
+// defaults for every run, and named profiles which override them.
+type configFile struct {
+	path     string
+	settings map[string]any
+	profiles map[string]map[string]any
+}
+
+// findConfigFile returns the path of the configuration file in dir, or "" if
+// there isn't one.
+func findConfigFile(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	for _, name := range configNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// loadConfigFile reads and parses the configuration file at path, which is
+// TOML unless it has a .yaml or .yml extension.
+func loadConfigFile(path string) (*configFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	default:
+		_, err = toml.Decode(string(data), &raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	f := &configFile{
+		path:     path,
+		settings: map[string]any{},
+		profiles: map[string]map[string]any{},
+	}
+	for key, value := range raw {
+		if key != "profiles" {
+			if err := flattenSetting(f.settings, key, value); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			continue
+		}
+
+		profiles, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: profiles should be a table of named profiles", path)
+		}
+		for name, profile := range profiles {
+			settings, ok := profile.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s: profile %q should be a table of settings", path, name)
+			}
+			f.profiles[name] = map[string]any{}
+			for key, value := range settings {
+				if err := flattenSetting(f.profiles[name], key, value); err != nil {
+					return nil, fmt.Errorf("%s: profile %q: %w", path, name, err)
+				}
+			}
+		}
+	}
+	return f, nil
+}
+
+// flattenSetting stores value under key in settings as a flag value: text, or
+// a list of text for a repeatable flag. Nested tables are joined with dots,
+// so a "foreground" key in a "spinner" table sets --spinner.foreground.
+func flattenSetting(settings map[string]any, key string, value any) error {
+	switch value := value.(type) {
+	case map[string]any:
+		for k, v := range value {
+			if err := flattenSetting(settings, key+"."+k, v); err != nil {
+				return err
+			}
+		}
+	case []any:
+		items := make([]any, len(value))
+		for i, item := range value {
+			items[i] = fmt.Sprint(item)
+		}
+		settings[key] = items
+	case nil:
+		return fmt.Errorf("%s has no value", key)
+	default:
+		settings[key] = fmt.Sprint(value)
+	}
+	return nil
+}
+
+// configResolver supplies flag values from the configuration file for flags
+// not given on the command line or by environment variable. The file named
+// by --config, or else the one in configDir, is loaded on first use, and the
+// profile named by --profile is applied over its defaults.
+type configResolver struct {
+	dir     string
+	loaded  bool
+	file    *configFile
+	profile string
+	// err is the error from loading the file, reported by Validate so that
+	// kong doesn't attribute it to whichever flag was being resolved.
+	err error
+}
+
+// Validate reports any error loading the file, and checks that every setting
+// in it is a known flag.
+func (r *configResolver) Validate(app *kong.Application) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.file == nil {
+		return nil
+	}
+
+	known := map[string]bool{}
+	for _, flag := range app.Flags {
+		known[flag.Name] = true
+	}
+	check := func(settings map[string]any, where string) error {
+		for _, key := range slices.Sorted(maps.Keys(settings)) {
+			if !known[key] || key == "config" || key == "profile" {
+				return fmt.Errorf("%s: unknown setting %q%s", r.file.path, key, where)
+			}
+		}
+		return nil
+	}
+
+	if err := check(r.file.settings, ""); err != nil {
+		return err
+	}
+	for _, name := range slices.Sorted(maps.Keys(r.file.profiles)) {
+		if err := check(r.file.profiles[name], fmt.Sprintf(" in profile %q", name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resolve returns the value for flag from the configuration file, or nil.
+func (r *configResolver) Resolve(ctx *kong.Context, _ *kong.Path, flag *kong.Flag) (any, error) {
+	if !r.loaded {
+		r.file, r.err = r.load(ctx)
+		r.loaded = true
+	}
+	if r.file == nil || flag.Name == "config" || flag.Name == "profile" || envSet(flag) {
+		return nil, nil
+	}
+	if slices.Contains(sourceFlags, flag.Name) && sourceGiven(ctx) {
+		return nil, nil
+	}
+	value, _, ok := r.lookup(flag.Name)
+	if !ok {
+		return nil, nil
+	}
+	return value, nil
+}
+
+// load reads the configuration file named by --config, or else the one in
+// the configuration directory, and checks the profile named by --profile. It
+// returns nil if there is no file.
+func (r *configResolver) load(ctx *kong.Context) (*configFile, error) {
+	path, _ := flagValue(ctx, "config").(string)
+	r.profile, _ = flagValue(ctx, "profile").(string)
+	if path == "" {
+		path = findConfigFile(r.dir)
+	}
+	if path == "" {
+		if r.profile != "" {
+			return nil, fmt.Errorf("profile %q given but there is no config file in %s", r.profile, r.dir)
+		}
+		return nil, nil
+	}
+
+	f, err := loadConfigFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("config file %s not found", path)
+		}
+		return nil, err
+	}
+	if _, ok := f.profiles[r.profile]; r.profile != "" && !ok {
+		return nil, fmt.Errorf("profile %q not found in %s", r.profile, path)
+	}
+	return f, nil
+}
+
+// themeDir returns the directory to look for theme files in: that of the
+// configuration file in use, or else the configuration directory.
+func (r *configResolver) themeDir() string {
+	if r.file != nil {
+		return filepath.Dir(r.file.path)
+	}
+	return r.dir
+}
+
+// lookup returns the value of the setting for the named flag, and describes
+// where it came from.
+func (r *configResolver) lookup(name string) (any, string, bool) {
+	if r.file == nil {
+		return nil, "", false
+	}
+	profile := r.file.profiles[r.profile]
+	if value, ok := profile[name]; ok {
+		return value, fmt.Sprintf("profile %s in %s", r.profile, r.file.path), true
+	}
+	if slices.Contains(sourceFlags, name) && slices.ContainsFunc(sourceFlags, func(s string) bool {
+		_, ok := profile[s]
+		return ok
+	}) {
+		return nil, "", false
+	}
+	if value, ok := r.file.settings[name]; ok {
+		return value, r.file.path, true
+	}
+	return nil, "", false
+}
+
+// showConfig prints each setting with its effective value and where the value
+// came from: a flag, an environment variable, the configuration file or the
+// built-in default.
+func showConfig(w io.Writer, ctx *kong.Context, r *configResolver) error {
+	resolved := map[*kong.Flag]bool{}
+	given := map[*kong.Flag]bool{}
+	for _, trace := range ctx.Path {
+		if trace.Flag != nil {
+			if trace.Resolved {
+				resolved[trace.Flag] = true
+			} else {
+				given[trace.Flag] = true
+			}
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SETTING\tVALUE\tSOURCE")
+	for _, flag := range ctx.Flags() {
+		if flag.Hidden || slices.Contains([]string{"help", "version"}, flag.Name) {
+			continue
+		}
+
+		source := "default"
+		switch {
+		case given[flag]:
+			source = "flag"
+		case resolved[flag]:
+			_, source, _ = r.lookup(flag.Name)
+		case envSet(flag):
+			source = "env " + setEnv(flag)
+		}
+		fmt.Fprintf(tw, "%s\t%q\t%s\n", flag.Name, fmt.Sprint(ctx.FlagValue(flag)), source)
+	}
+	return tw.Flush()
+}
+
+// sourceGiven reports whether what to count has been given on the command
+// line, as a flag or the duration argument, or by the stopwatch command.
+func sourceGiven(ctx *kong.Context) bool {
+	for _, trace := range ctx.Path {
+		if trace.Positional != nil || trace.Command != nil && trace.Command.Name == "stopwatch" {
+			return true
+		}
+		if trace.Flag != nil && !trace.Resolved && slices.Contains(sourceFlags, trace.Flag.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadTheme returns the theme with the given name: a theme file in the themes
+// directory within dir, or else a built-in theme.
+func loadTheme(name, dir string) (countdown.Theme, error) {
+	if dir != "" && filepath.Base(name) == name {
+		for _, ext := range []string{".toml", ".yaml", ".yml"} {
+			path := filepath.Join(dir, "themes", name+ext)
+			if _, err := os.Stat(path); err == nil {
+				return loadThemeFile(name, path)
+			}
+		}
+	}
+	if theme, ok := countdown.LookupTheme(name); ok {
+		return theme, nil
+	}
+	return countdown.Theme{}, fmt.Errorf("unknown theme %q, expected one of %s or a file in %s",
+		name, strings.Join(countdown.ThemeNames(), ", "), filepath.Join(dir, "themes"))
+}
+
+// themeFile is the layout of a theme file. Top-level colors apply to both
+// variants, and the light and dark tables override them.
+type themeFile struct {
+	countdown.Palette `yaml:",inline"`
+	Light             countdown.Palette `toml:"light" yaml:"light"`
+	Dark              countdown.Palette `toml:"dark" yaml:"dark"`
+}
+
+// loadThemeFile reads a theme from the TOML or YAML file at path.
+func loadThemeFile(name, path string) (countdown.Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return countdown.Theme{}, err
+	}
+
+	var f themeFile
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err = dec.Decode(&f); errors.Is(err, io.EOF) {
+			err = nil
+		}
+	default:
+		var md toml.MetaData
+		md, err = toml.Decode(string(data), &f)
+		if undecoded := md.Undecoded(); err == nil && len(undecoded) > 0 {
+			err = fmt.Errorf("unknown setting %q", undecoded[0].String())
+		}
+	}
+	if err != nil {
+		return countdown.Theme{}, fmt.Errorf("%s: %w", path, err)
+	}
+	err = cmp.Or(checkPalette("", f.Palette), checkPalette("light.", f.Light), checkPalette("dark.", f.Dark))
+	if err != nil {
+		return countdown.Theme{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return countdown.Theme{
+		Name:  name,
+		Light: overlayPalette(f.Palette, f.Light),
+		Dark:  overlayPalette(f.Palette, f.Dark),
+	}, nil
+}
+
+// checkPalette reports the first color of a palette which isn't a color,
+// named as in a theme file after prefix.
+func checkPalette(prefix string, p countdown.Palette) error {
+	colors := []struct{ key, color string }{
+		{"spinner", p.Spinner},
+		{"title", p.Title},
+		{"count", p.Count},
+		{"final-foreground", p.FinalForeground},
+		{"final-background", p.FinalBackground},
+		{"background", p.Background},
+		{"overtime", p.Overtime},
+	}
+	for _, c := range colors {
+		if c.color != "" && !countdown.ValidColor(c.color) {
+			return fmt.Errorf("invalid color %q for %s", c.color, prefix+c.key)
+		}
+	}
+	return nil
+}
+
+// overlayPalette returns base with the colors set in over replacing its own.
+func overlayPalette(base, over countdown.Palette) countdown.Palette {
+	return countdown.Palette{
+		Spinner:         cmp.Or(over.Spinner, base.Spinner),
+		Title:           cmp.Or(over.Title, base.Title),
+		Count:           cmp.Or(over.Count, base.Count),
+		FinalForeground: cmp.Or(over.FinalForeground, base.FinalForeground),
+		FinalBackground: cmp.Or(over.FinalBackground, base.FinalBackground),
+		Background:      cmp.Or(over.Background, base.Background),
+		Overtime:        cmp.Or(over.Overtime, base.Overtime),
+	}
+}
+
+// flagValue returns the value of the named flag, or nil if there is no such flag.
+func flagValue(ctx *kong.Context, name string) any {
+	for _, flag := range ctx.Flags() {
+		if flag.Name == name {
+			return ctx.FlagValue(flag)
+		}
+	}
+	return nil
+}
+
+// envSet reports whether the flag has been given by environment variable.
+func envSet(flag *kong.Flag) bool {
+	return setEnv(flag) != ""
+}
+
+// setEnv returns the name of the environment variable giving the flag, or "".
+func setEnv(flag *kong.Flag) string {
+	for _, env := range flag.Envs {
+		if _, ok := os.LookupEnv(env); ok {
+			return env
+		}
+	}
+	return ""
+}
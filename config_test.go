@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/countdown/countdown/internal/countdown"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfigTOML = `
+spinner = "moon"
+range = "30..0"
+"spinner.foreground" = "#ff0000"
+time-interval = 0.5
+
+[title]
+background = "57"
+
+[profiles.standup]
+title = "Standup ends in"
+duration = "15m"
+precision = 1
+big = true
+`
+
+const testConfigYAML = `
+spinner: moon
+range: "30..0"
+spinner.foreground: "#ff0000"
+time-interval: 0.5
+title:
+  background: "57"
+profiles:
+  standup:
+    title: Standup ends in
+    duration: 15m
+    precision: 1
+    big: true
+`
+
+// writeConfig writes a configuration file to a new directory and returns the
+// directory.
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	return dir
+}
+
+// parseWithConfig parses args with configuration from dir.
+func parseWithConfig(dir string, args ...string) (CLI, *kong.Context, *configResolver, error) {
+	var cli CLI
+	resolver := &configResolver{dir: dir}
+	parser, err := kong.New(&cli, kong.Name("countdown"), kong.Resolvers(resolver))
+	if err != nil {
+		return cli, nil, nil, err
+	}
+	ctx, err := parser.Parse(args)
+	return cli, ctx, resolver, err
+}
+
+func TestConfigFile(t *testing.T) {
+	for _, file := range []struct{ name, content string }{
+		{"config.toml", testConfigTOML},
+		{"config.yaml", testConfigYAML},
+	} {
+		t.Run(file.name, func(t *testing.T) {
+			dir := writeConfig(t, file.name, file.content)
+
+			cli, _, _, err := parseWithConfig(dir)
+			require.NoError(t, err)
+			assert.Equal(t, "moon", cli.Spinner)
+			assert.Equal(t, "30..0", cli.Range)
+			assert.Equal(t, "#ff0000", cli.SpinnerStyle.Foreground)
+			assert.Equal(t, "57", cli.TitleStyle.Background)
+			assert.Equal(t, "0.5", cli.TimeInterval)
+			assert.Equal(t, "Liftoff in", cli.Title, "Unset values should keep their defaults")
+			assert.Equal(t, "", cli.Duration)
+
+			cli, ctx, _, err := parseWithConfig(dir, "--profile", "standup")
+			require.NoError(t, err)
+			assert.Equal(t, "Standup ends in", cli.Title)
+			assert.Equal(t, "15m", cli.Duration)
+			assert.False(t, isFlagSet(ctx, "range"), "A source in the profile should replace the file's")
+			assert.Equal(t, 1, cli.Precision)
+			assert.True(t, cli.Big)
+			assert.Equal(t, "moon", cli.Spinner, "The profile should apply over the file's defaults")
+		})
+	}
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	dir := writeConfig(t, "config.toml", testConfigTOML)
+
+	cli, _, _, err := parseWithConfig(dir, "--profile", "standup", "--title", "Flag", "-p", "2", "-r", "5..0")
+	require.NoError(t, err)
+	assert.Equal(t, "Flag", cli.Title, "Flags should override the file")
+	assert.Equal(t, 2, cli.Precision)
+	assert.Equal(t, "5..0", cli.Range)
+	assert.Equal(t, "", cli.Duration, "A source given as a flag should replace the file's")
+
+	cli, ctx, _, err := parseWithConfig(dir, "10m")
+	require.NoError(t, err)
+	assert.Equal(t, "10m", cli.Count.Length)
+	assert.False(t, isFlagSet(ctx, "range"), "A duration argument should replace the file's source")
+
+	_, ctx, _, err = parseWithConfig(dir, "stopwatch")
+	require.NoError(t, err)
+	assert.False(t, isFlagSet(ctx, "range"), "The stopwatch command should replace the file's source")
+
+	t.Setenv("COUNTDOWN_SPINNER", "line")
+	cli, _, _, err = parseWithConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "line", cli.Spinner, "Environment variables should override the file")
+}
+
+func TestConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		args    []string
+		wantErr string
+	}{
+		{"unknown setting", "colour = \"red\"\n", nil, `unknown setting "colour"`},
+		{"unknown setting in profile", "[profiles.x]\nspiner = \"dot\"\n", nil, `unknown setting "spiner" in profile "x"`},
+		{"unknown profile", testConfigTOML, []string{"--profile", "retro"}, `profile "retro" not found`},
+		{"invalid toml", "title = \n", nil, "config.toml"},
+		{"invalid value", "precision = \"lots\"\n", nil, "precision"},
+		{"profiles not a table", "profiles = 3\n", nil, "profiles should be a table"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeConfig(t, "config.toml", tt.content)
+			_, _, _, err := parseWithConfig(dir, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, _, _, err := parseWithConfig(t.TempDir(), "--profile", "standup")
+	assert.ErrorContains(t, err, "no config file", "A profile needs a config file")
+
+	_, _, _, err = parseWithConfig(t.TempDir(), "--config", filepath.Join(t.TempDir(), "missing.toml"))
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestConfigFlag(t *testing.T) {
+	dir := writeConfig(t, "other.yml", "title: From flag\n")
+
+	cli, _, _, err := parseWithConfig(t.TempDir(), "--config", filepath.Join(dir, "other.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "From flag", cli.Title)
+}
+
+func TestThemeDir(t *testing.T) {
+	dir := t.TempDir()
+	_, _, resolver, err := parseWithConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, resolver.themeDir())
+
+	other := writeConfig(t, "other.toml", "")
+	_, _, resolver, err = parseWithConfig(dir, "--config", filepath.Join(other, "other.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, other, resolver.themeDir(), "Themes should be found beside the config file given")
+}
+
+func TestShowConfig(t *testing.T) {
+	dir := writeConfig(t, "config.toml", testConfigTOML)
+	t.Setenv("COUNTDOWN_PADDING", "1 2")
+
+	_, ctx, resolver, err := parseWithConfig(dir, "--profile", "standup", "-s", "dot")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, showConfig(&buf, ctx, resolver))
+	out := buf.String()
+
+	path := filepath.Join(dir, "config.toml")
+	assert.Regexp(t, `(?m)^spinner\s+"dot"\s+flag$`, out)
+	assert.Regexp(t, `(?m)^title\s+"Standup ends in"\s+profile standup in `+regexp.QuoteMeta(path)+`$`, out)
+	assert.Regexp(t, `(?m)^time-interval\s+"0.5"\s+`+regexp.QuoteMeta(path)+`$`, out)
+	assert.Regexp(t, `(?m)^padding\s+"1 2"\s+env COUNTDOWN_PADDING$`, out)
+	assert.Regexp(t, `(?m)^grace\s+"5s"\s+default$`, out)
+	assert.NotContains(t, out, "help")
+}
+
+func TestLoadTheme(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "themes"), 0o700))
+	files := map[string]string{
+		"ocean.toml":  "spinner = \"39\"\ncount = \"45\"\n\n[light]\nbackground = \"15\"\n\n[dark]\nbackground = \"17\"\n",
+		"forest.yaml": "spinner: \"34\"\ndark:\n  title: \"120\"\n",
+		"broken.toml": "spiner = \"39\"\n",
+		"dracula.yml": "spinner: \"1\"\n",
+		"faded.toml":  "count = \"#00ff0\"\n",
+		"grass.yaml":  "dark:\n  title: gren\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "themes", name), []byte(content), 0o600))
+	}
+
+	theme, err := loadTheme("ocean", dir)
+	require.NoError(t, err)
+	assert.Equal(t, "ocean", theme.Name)
+	assert.Equal(t, countdown.Palette{Spinner: "39", Count: "45", Background: "15"}, theme.Light)
+	assert.Equal(t, countdown.Palette{Spinner: "39", Count: "45", Background: "17"}, theme.Dark)
+
+	theme, err = loadTheme("forest", dir)
+	require.NoError(t, err)
+	assert.Equal(t, countdown.Palette{Spinner: "34"}, theme.Light)
+	assert.Equal(t, countdown.Palette{Spinner: "34", Title: "120"}, theme.Dark)
+
+	theme, err = loadTheme("dracula", dir)
+	require.NoError(t, err)
+	assert.Equal(t, "1", theme.Dark.Spinner, "A theme file should replace the built-in theme of the same name")
+
+	theme, err = loadTheme("solarized", dir)
+	require.NoError(t, err)
+	assert.Equal(t, "solarized", theme.Name)
+
+	_, err = loadTheme("broken", dir)
+	assert.ErrorContains(t, err, `unknown setting "spiner"`)
+
+	_, err = loadTheme("faded", dir)
+	assert.ErrorContains(t, err, `faded.toml: invalid color "#00ff0" for count`)
+	_, err = loadTheme("grass", dir)
+	assert.ErrorContains(t, err, `grass.yaml: invalid color "gren" for dark.title`)
+
+	_, err = loadTheme("neon", dir)
+	assert.ErrorContains(t, err, `unknown theme "neon"`)
+}
+
+func TestConfigFileLists(t *testing.T) {
+	dir := writeConfig(t, "config.toml", "stage = [\"20%:yellow\", \"10%:red,blink\"]\n")
+
+	cli, _, _, err := parseWithConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20%:yellow", "10%:red,blink"}, cli.Stage, "Lists should give each value of a repeatable flag")
+}
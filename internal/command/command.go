@@ -0,0 +1,115 @@
+// Package command runs the child processes which countdown starts.
+package command
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// Shell returns a command which runs line with the system shell.
+func Shell(line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", line)
+	}
+	return exec.Command("sh", "-c", line)
+}
+
+// Args returns a command which runs argv directly, without a shell.
+func Args(argv []string) *exec.Cmd {
+	return exec.Command(argv[0], argv[1:]...)
+}
+
+// Run runs the command attached to the terminal, with its output written to
+// stdout, and returns its exit status. A command killed by a signal exits
+// with 128 plus the signal number, as it would in a shell. The error is only
+// set when the command could not be run.
+func Run(cmd *exec.Cmd, stdout io.Writer) (int, error) {
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, stdout, os.Stderr
+	return ExitStatus(cmd.Run())
+}
+
+// ExitStatus converts the error from waiting on a command into its exit
+// status. The error is returned unchanged when the command did not run to an
+// exit status.
+func ExitStatus(err error) (int, error) {
+	if err == nil {
+		return 0, nil
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return 0, err
+	}
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal()), nil
+	}
+	return exitErr.ExitCode(), nil
+}
+
+// waitDelay is how long a child which has exited is waited on for the rest
+// of its output.
+const waitDelay = time.Second
+
+// Child is a command running in the background.
+type Child struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+	err  error
+}
+
+// Start starts the command in the background with its output written to
+// stdout and stderr, and without the terminal's input, which stays with
+// countdown.
+func Start(cmd *exec.Cmd, stdout, stderr io.Writer) (*Child, error) {
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	// Don't wait long on output held open by the command's own children
+	cmd.WaitDelay = waitDelay
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	c := &Child{cmd: cmd, done: make(chan struct{})}
+	go func() {
+		c.err = cmd.Wait()
+		close(c.done)
+	}()
+	return c, nil
+}
+
+// Done returns a channel which is closed when the child exits.
+func (c *Child) Done() <-chan struct{} {
+	return c.done
+}
+
+// ExitStatus waits for the child to exit and returns its exit status.
+func (c *Child) ExitStatus() (int, error) {
+	<-c.done
+	return ExitStatus(c.err)
+}
+
+// Terminate asks the child to exit with SIGTERM, then kills it if it is still
+// running after the grace period. It returns once the child has exited.
+func (c *Child) Terminate(grace time.Duration) {
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+
+	if err := c.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		// Not supported on Windows, or the child has just exited
+		_ = c.cmd.Process.Kill()
+	}
+
+	select {
+	case <-c.done:
+	case <-time.After(grace):
+		_ = c.cmd.Process.Kill()
+		<-c.done
+	}
+}
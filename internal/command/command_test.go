@@ -0,0 +1,117 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	tests := []struct {
+		name string
+		line string
+		want int
+	}{
+		{"success", "true", 0},
+		{"failure", "exit 3", 3},
+		{"killed by signal", "kill -TERM $$", 143},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Run(Shell(tt.line), io.Discard)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunArgs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	got, err := Run(Args([]string{"sh", "-c", "exit $0", "7"}), io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, 7, got)
+
+	_, err = Run(Args([]string{"countdown-no-such-command"}), io.Discard)
+	require.Error(t, err, "A command which cannot start should return an error")
+}
+
+func TestExitStatusPassesOtherErrors(t *testing.T) {
+	want := errors.New("boom")
+	_, err := ExitStatus(want)
+	assert.Equal(t, want, err)
+}
+
+func TestChildExits(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	c, err := Start(Shell("exit 4"), io.Discard, io.Discard)
+	require.NoError(t, err)
+
+	<-c.Done()
+	status, err := c.ExitStatus()
+	require.NoError(t, err)
+	assert.Equal(t, 4, status)
+}
+
+func TestChildOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	var stdout, stderr bytes.Buffer
+	c, err := Start(Shell("echo out; echo err >&2"), &stdout, &stderr)
+	require.NoError(t, err)
+
+	<-c.Done()
+	assert.Equal(t, "out\n", stdout.String())
+	assert.Equal(t, "err\n", stderr.String())
+}
+
+func TestChildTerminate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	c, err := Start(Args([]string{"sleep", "60"}), io.Discard, io.Discard)
+	require.NoError(t, err)
+
+	c.Terminate(time.Second)
+	status, err := c.ExitStatus()
+	require.NoError(t, err)
+	assert.Equal(t, 143, status)
+}
+
+func TestChildTerminateKillsAfterGrace(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	// The shell ignores SIGTERM, so only SIGKILL stops it
+	c, err := Start(Shell("trap '' TERM; while true; do sleep 0.01; done"), io.Discard, io.Discard)
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	c.Terminate(200 * time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+
+	status, err := c.ExitStatus()
+	require.NoError(t, err)
+	assert.Equal(t, 137, status)
+}
@@ -0,0 +1,78 @@
+package countdown
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// blend returns the color t of the way from one color to another, from 0 to
+// 1. Colors are blended in OKLab, where equal steps look equally far apart,
+// so a blend from green to red doesn't pass through a muddy brown.
+func blend(from, to string, t float64) lipgloss.Color {
+	l1, a1, b1 := toOKLab(colorToRGB(from))
+	l2, a2, b2 := toOKLab(colorToRGB(to))
+	mix := func(x, y float64) float64 {
+		return x + (y-x)*t
+	}
+	return rgbColor(fromOKLab(mix(l1, l2), mix(a1, a2), mix(b1, b2)))
+}
+
+// rgbColor returns a color as a hex code, which lipgloss brings down to the
+// terminal's color profile.
+func rgbColor(r, g, b uint8) lipgloss.Color {
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", r, g, b))
+}
+
+// toOKLab converts an sRGB color to OKLab lightness and a and b axes.
+func toOKLab(r, g, b uint8) (float64, float64, float64) {
+	lr, lg, lb := srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)
+
+	l := math.Cbrt(0.4122214708*lr + 0.5363325363*lg + 0.0514459929*lb)
+	m := math.Cbrt(0.2119034982*lr + 0.6806995451*lg + 0.1073969566*lb)
+	s := math.Cbrt(0.0883024619*lr + 0.2817188376*lg + 0.6299787005*lb)
+
+	return 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+}
+
+// fromOKLab converts an OKLab color to sRGB, clipping colors outside it.
+func fromOKLab(L, A, B float64) (r, g, b uint8) {
+	l := math.Pow(L+0.3963377774*A+0.2158037573*B, 3)
+	m := math.Pow(L-0.1055613458*A-0.0638541728*B, 3)
+	s := math.Pow(L-0.0894841775*A-1.2914855480*B, 3)
+
+	return linearToSRGB(4.0767416621*l - 3.3077115913*m + 0.2309699292*s),
+		linearToSRGB(-1.2684380046*l + 2.6097574011*m - 0.3413193965*s),
+		linearToSRGB(-0.0041960863*l - 0.7034186147*m + 1.7076147010*s)
+}
+
+// srgbToLinear converts an sRGB channel to linear light, from 0 to 1.
+func srgbToLinear(v uint8) float64 {
+	f := float64(v) / 255
+	if f <= 0.04045 {
+		return f / 12.92
+	}
+	return math.Pow((f+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB converts linear light to an sRGB channel.
+func linearToSRGB(f float64) uint8 {
+	if f <= 0.0031308 {
+		f *= 12.92
+	} else {
+		f = 1.055*math.Pow(f, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(min(max(f, 0), 1) * 255))
+}
+
+// countColor returns the color of the count blended from Config.ColorFrom
+// to Config.ColorTo by how far it has got, and whether they are set.
+func (m Model) countColor() (lipgloss.Color, bool) {
+	if m.config.ColorFrom == "" || m.config.ColorTo == "" {
+		return "", false
+	}
+	return blend(m.config.ColorFrom, m.config.ColorTo, m.percent()), true
+}
@@ -0,0 +1,84 @@
+package countdown
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlend(t *testing.T) {
+	assert.Equal(t, lipgloss.Color("#000000"), blend("#000000", "#ffffff", 0))
+	assert.Equal(t, lipgloss.Color("#636363"), blend("#000000", "#ffffff", 0.5), "Colors should blend by how light they look, not by RGB")
+	assert.Equal(t, lipgloss.Color("#ffffff"), blend("0", "15", 1), "ANSI colors should blend by their RGB values")
+	assert.Equal(t, lipgloss.Color("#ff0000"), blend("green", "#ff0000", 1), "ANSI colors should blend by name")
+}
+
+func TestOKLab(t *testing.T) {
+	for _, hex := range []string{"#000000", "#ffffff", "#ff0000", "#00ff00", "#0000ff", "#5a56e0", "#ee6ff8"} {
+		l, a, b := toOKLab(hexToRGB(hex))
+		assert.Equal(t, lipgloss.Color(hex), rgbColor(fromOKLab(l, a, b)), "%s should survive the round trip", hex)
+	}
+
+	l, a, b := toOKLab(255, 255, 255)
+	assert.InDelta(t, 1, l, 1e-4)
+	assert.InDelta(t, 0, a, 1e-4, "White should have no hue")
+	assert.InDelta(t, 0, b, 1e-4, "White should have no hue")
+
+	assert.Equal(t, lipgloss.Color("#ffffff"), rgbColor(fromOKLab(2, 0, 0)), "Colors out of range should be clipped")
+}
+
+func TestModelCountColor(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	cfg := Config{Start: 10, End: 0, TimeInterval: time.Second, Decrement: 1, Clock: clock}
+
+	_, ok := NewModel(cfg).countColor()
+	assert.False(t, ok, "The count should keep its color without both ends")
+	cfg.ColorFrom = "#00ff00"
+	_, ok = NewModel(cfg).countColor()
+	assert.False(t, ok, "The count should keep its color without both ends")
+
+	cfg.ColorTo = "#ff0000"
+	m := NewModel(cfg)
+	assert.Empty(t, m.stages, "Blending should take the place of the final phase")
+
+	color, ok := m.countColor()
+	assert.True(t, ok)
+	assert.Equal(t, lipgloss.Color("#00ff00"), color)
+
+	clock.Advance(5 * time.Second)
+	m.advance()
+	color, _ = m.countColor()
+	assert.Equal(t, blend("#00ff00", "#ff0000", 0.5), color)
+
+	clock.Advance(5 * time.Second)
+	m.advance()
+	color, _ = m.countColor()
+	assert.Equal(t, lipgloss.Color("#ff0000"), color)
+
+	cfg.Stages = []Stage{{Threshold: 3, Foreground: "blue"}}
+	m = NewModel(cfg)
+	assert.Len(t, m.stages, 1, "Given stages should still be used")
+}
+
+func TestBlendProfiles(t *testing.T) {
+	tests := []struct {
+		profile termenv.Profile
+		want    string
+	}{
+		{termenv.TrueColor, "\x1b[38;2;0;255;0m"},
+		{termenv.ANSI256, "\x1b[38;5;46m"},
+		{termenv.ANSI, "\x1b[92m"},
+		{termenv.Ascii, "10"},
+	}
+
+	for _, tt := range tests {
+		r := lipgloss.NewRenderer(io.Discard)
+		r.SetColorProfile(tt.profile)
+		style := r.NewStyle().Foreground(blend("#00ff00", "#ff0000", 0))
+		assert.Contains(t, style.Render("10"), tt.want)
+	}
+}
@@ -0,0 +1,141 @@
+package countdown
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClockAfter(t *testing.T) {
+	start := time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	first := clock.After(time.Second)
+	second := clock.After(3 * time.Second)
+	immediate := clock.After(0)
+
+	assert.Equal(t, start, <-immediate)
+
+	clock.Advance(500 * time.Millisecond)
+	assert.Empty(t, first, "Waiter should not fire before its time")
+
+	clock.Advance(time.Second)
+	assert.Equal(t, start.Add(1500*time.Millisecond), <-first)
+	assert.Empty(t, second)
+
+	clock.Set(start)
+	assert.Empty(t, second, "Setting the clock backwards should not fire waiters")
+
+	clock.Set(start.Add(time.Hour))
+	assert.Equal(t, start.Add(time.Hour), <-second)
+}
+
+func TestFakeClockBlockUntil(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+
+	fired := make(chan time.Time)
+	go func() {
+		fired <- <-clock.After(time.Minute)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	assert.Equal(t, clock.Now(), <-fired)
+}
+
+// runToCompletion drives a model by executing its tick commands against the
+// fake clock, returning the counts seen after each tick.
+func runToCompletion(t *testing.T, m Model, clock *FakeClock) (Model, []int) {
+	t.Helper()
+
+	var seen []int
+	cmd := m.tick()
+	for i := 0; i < 1000; i++ {
+		msgs := make(chan tea.Msg, 1)
+		go func(cmd tea.Cmd) { msgs <- cmd() }(cmd)
+
+		clock.BlockUntil(1)
+		clock.Advance(m.engine.untilNext(clock.Now()))
+
+		updated, next := m.Update(<-msgs)
+		m = updated.(Model)
+		seen = append(seen, m.current)
+		require.NotNil(t, next)
+		if m.done {
+			// Only the quit command is left to run
+			_, ok := next().(tea.QuitMsg)
+			require.True(t, ok, "A finished countdown should quit")
+			return m, seen
+		}
+		cmd = next
+	}
+	t.Fatal("countdown did not finish")
+	return m, seen
+}
+
+func TestModelRunsToCompletion(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	start := clock.Now()
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        10,
+		End:          0,
+		TimeInterval: 2 * time.Second,
+		Decrement:    3,
+		FinalPhase:   4,
+		Clock:        clock,
+	}
+
+	m, seen := runToCompletion(t, NewModel(cfg), clock)
+
+	assert.Equal(t, []int{7, 4, 1, 0}, seen)
+	assert.True(t, m.done)
+	assert.Equal(t, 8*time.Second, clock.Now().Sub(start))
+}
+
+func TestModelRunsToCompletionWithTenths(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	start := clock.Now()
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        10,
+		End:          0,
+		TimeInterval: 100 * time.Millisecond,
+		Decrement:    1,
+		Precision:    1,
+		Clock:        clock,
+	}
+
+	m, seen := runToCompletion(t, NewModel(cfg), clock)
+
+	assert.Equal(t, []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, seen)
+	assert.True(t, m.done)
+	assert.Equal(t, time.Second, clock.Now().Sub(start))
+}
+
+func TestModelRunsToCompletionThroughFinalPhase(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        0,
+		End:          5,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		FinalPhase:   3,
+		Clock:        clock,
+	}
+
+	m := NewModel(cfg)
+	assert.False(t, m.isInFinalPhase())
+
+	m, seen := runToCompletion(t, m, clock)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, seen)
+	assert.True(t, m.isInFinalPhase())
+	assert.Empty(t, m.View(), "A finished countdown should render nothing")
+}
@@ -0,0 +1,200 @@
+package countdown
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DigitStyle selects how the big digits are colored.
+type DigitStyle int
+
+const (
+	// DigitStyleSolid colors the whole count in its style.
+	DigitStyleSolid DigitStyle = iota
+	// DigitStyleGradient blends from the first color to the second across
+	// the count.
+	DigitStyleGradient
+	// DigitStyleVertical blends from the first color to the second down the
+	// count.
+	DigitStyleVertical
+	// DigitStyleRainbow colors each character of the count a color of the
+	// rainbow in turn.
+	DigitStyleRainbow
+	// DigitStyleChanged colors only the characters which changed when the
+	// count last did, in the first color.
+	DigitStyleChanged
+)
+
+// defaultDigitColors are used without Config.DigitColors, and are the ends
+// of the progress bar's default gradient.
+var defaultDigitColors = []string{"#5A56E0", "#EE6FF8"}
+
+// cell is one character of text drawn in a font.
+type cell struct {
+	char rune
+	// glyph is the index of the character of the text the cell was drawn
+	// for, and color its foreground, or nil for the style's own.
+	glyph int
+	color lipgloss.TerminalColor
+}
+
+// grid is text drawn in a font as lines of cells, so that each can be
+// colored on its own.
+type grid [][]cell
+
+// grid draws text in the font as cells. Each column belongs to the first
+// character whose glyph reaches it, which for FIGlet fonts which smush
+// glyphs together is as near as it gets.
+func (f Font) grid(text string) grid {
+	// Where each character's glyph ends
+	var ends []int
+	for i, r := range text {
+		ends = append(ends, f.Width(text[:i+utf8.RuneLen(r)]))
+	}
+
+	rendered := f.Render(text)
+	if rendered == "" {
+		return nil
+	}
+	lines := strings.Split(rendered, "\n")
+	g := make(grid, len(lines))
+	for y, line := range lines {
+		glyph := 0
+		for x, r := range []rune(line) {
+			for glyph < len(ends)-1 && x >= ends[glyph] {
+				glyph++
+			}
+			g[y] = append(g[y], cell{char: r, glyph: glyph})
+		}
+	}
+	return g
+}
+
+// render draws the grid in the style, with each cell's color as its
+// foreground. Cells of the same color are drawn together.
+func (g grid) render(style lipgloss.Style) string {
+	lines := make([]string, len(g))
+	for y, row := range g {
+		var b strings.Builder
+		for start := 0; start < len(row); {
+			end := start + 1
+			for end < len(row) && row[end].color == row[start].color {
+				end++
+			}
+			chars := make([]rune, end-start)
+			for i, c := range row[start:end] {
+				chars[i] = c.char
+			}
+			s := style
+			if row[start].color != nil {
+				s = s.Foreground(row[start].color)
+			}
+			b.WriteString(s.Render(string(chars)))
+			start = end
+		}
+		lines[y] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderBigCount draws the count in big digits in the style, colored by
+// Config.DigitStyle. A stage's style takes over from the digit style, so
+// that it stands out.
+func (m Model) renderBigCount(style lipgloss.Style, inStage bool) string {
+	text := m.formatCount()
+	if m.config.DigitStyle == DigitStyleSolid || inStage {
+		return style.Render(m.config.Font.Render(text))
+	}
+	g := m.config.Font.grid(text)
+	m.colorDigits(g, text)
+	return g.render(style)
+}
+
+// colorDigits colors the cells of the count by Config.DigitStyle.
+func (m Model) colorDigits(g grid, text string) {
+	colors := m.config.DigitColors
+	if len(colors) == 0 {
+		colors = defaultDigitColors
+	}
+	from, to := colors[0], colors[len(colors)-1]
+	changed := changedChars(m.changedFrom, text)
+	glyphs := utf8.RuneCountInString(text)
+
+	for y, row := range g {
+		for x := range row {
+			c := &row[x]
+			switch m.config.DigitStyle {
+			case DigitStyleGradient:
+				c.color = blendRGB(from, to, fraction(x, len(row)))
+			case DigitStyleVertical:
+				c.color = blendRGB(from, to, fraction(y, len(g)))
+			case DigitStyleRainbow:
+				c.color = rainbow(float64(c.glyph) / float64(glyphs))
+			case DigitStyleChanged:
+				if changed[c.glyph] {
+					c.color = parseColor(from)
+				}
+			}
+		}
+	}
+}
+
+// fraction returns how far i is through n steps, from 0 to 1.
+func fraction(i, n int) float64 {
+	if n < 2 {
+		return 0
+	}
+	return float64(i) / float64(n-1)
+}
+
+// changedChars reports which characters of text differ from those of the
+// text before, lining them up from the right as the digits of a count are.
+// Nothing has changed when there was no text before.
+func changedChars(before, text string) []bool {
+	now, was := []rune(text), []rune(before)
+	changed := make([]bool, len(now))
+	if len(was) == 0 {
+		return changed
+	}
+	offset := len(now) - len(was)
+	for i, r := range now {
+		changed[i] = i-offset < 0 || was[i-offset] != r
+	}
+	return changed
+}
+
+// blendRGB returns the color t of the way from one color to another, from 0
+// to 1, mixing their RGB values. Digit gradients keep to it rather than the
+// OKLab blend of the count's color, so that they look as they always have.
+func blendRGB(from, to string, t float64) lipgloss.Color {
+	r1, g1, b1 := colorToRGB(from)
+	r2, g2, b2 := colorToRGB(to)
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*t))
+	}
+	return rgbColor(mix(r1, r2), mix(g1, g2), mix(b1, b2))
+}
+
+// rainbow returns the color of the rainbow at hue h, from 0 to 1, at full
+// saturation and brightness.
+func rainbow(h float64) lipgloss.Color {
+	h = math.Mod(h, 1) * 6
+	x := uint8(math.Round(255 * (1 - math.Abs(math.Mod(h, 2)-1))))
+	switch int(h) {
+	case 0:
+		return rgbColor(255, x, 0)
+	case 1:
+		return rgbColor(x, 255, 0)
+	case 2:
+		return rgbColor(0, 255, x)
+	case 3:
+		return rgbColor(0, x, 255)
+	case 4:
+		return rgbColor(x, 0, 255)
+	default:
+		return rgbColor(255, 0, x)
+	}
+}
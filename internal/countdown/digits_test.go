@@ -0,0 +1,119 @@
+package countdown
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFontGrid(t *testing.T) {
+	g := fonts[DefaultFont].grid("1:0")
+	require.Len(t, g, 6)
+	glyphs := make([]int, len(g[0]))
+	for x, c := range g[0] {
+		glyphs[x] = c.glyph
+	}
+	assert.Equal(t, []int{0, 0, 0, 0, 0, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2}, glyphs)
+	assert.Equal(t, renderBigText("1:0"), g.render(lipgloss.NewStyle()), "The grid should draw the same text")
+
+	assert.Nil(t, fonts[DefaultFont].grid(""))
+}
+
+func TestColorDigits(t *testing.T) {
+	cfg := Config{Start: 10, End: 0, Big: true, DigitColors: []string{"#000000", "#ffffff"}}
+	colors := func(style DigitStyle, changedFrom string) grid {
+		cfg.DigitStyle = style
+		m := NewModel(cfg)
+		m.changedFrom = changedFrom
+		g := m.config.Font.grid(m.formatCount())
+		m.colorDigits(g, m.formatCount())
+		return g
+	}
+
+	g := colors(DigitStyleGradient, "")
+	last := len(g[0]) - 1
+	assert.Equal(t, lipgloss.Color("#000000"), g[0][0].color)
+	assert.Equal(t, lipgloss.Color("#000000"), g[5][0].color, "A gradient across should be the same down each column")
+	assert.Equal(t, lipgloss.Color("#767676"), g[0][6].color, "Gradients should blend by RGB")
+	assert.Equal(t, lipgloss.Color("#ffffff"), g[0][last].color)
+
+	g = colors(DigitStyleVertical, "")
+	assert.Equal(t, lipgloss.Color("#000000"), g[0][last].color)
+	assert.Equal(t, lipgloss.Color("#ffffff"), g[5][0].color)
+
+	g = colors(DigitStyleRainbow, "")
+	assert.Equal(t, lipgloss.Color("#ff0000"), g[0][0].color)
+	assert.Equal(t, lipgloss.Color("#00ffff"), g[0][last].color, "Each character should be a color of the rainbow in turn")
+
+	g = colors(DigitStyleChanged, "11")
+	assert.Nil(t, g[0][0].color, "A digit which didn't change should be left in the count's style")
+	assert.Equal(t, lipgloss.Color("#000000"), g[0][last].color)
+
+	cfg.DigitColors = nil
+	g = colors(DigitStyleGradient, "")
+	assert.Equal(t, lipgloss.Color(strings.ToLower(defaultDigitColors[0])), g[0][0].color)
+}
+
+func TestBlendRGB(t *testing.T) {
+	assert.Equal(t, lipgloss.Color("#000000"), blendRGB("#000000", "#ffffff", 0))
+	assert.Equal(t, lipgloss.Color("#808080"), blendRGB("#000000", "#ffffff", 0.5))
+	assert.Equal(t, lipgloss.Color("#ffffff"), blendRGB("0", "15", 1), "ANSI colors should blend by their RGB values")
+}
+
+func TestChangedChars(t *testing.T) {
+	tests := []struct {
+		before, text string
+		want         []bool
+	}{
+		{"", "10", []bool{false, false}},
+		{"11", "10", []bool{false, true}},
+		{"10", "09", []bool{true, true}},
+		{"10", "9", []bool{true}},
+		{"9", "10", []bool{true, true}},
+		{"01:00", "00:59", []bool{false, true, false, true, true}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, changedChars(tt.before, tt.text), "%q to %q", tt.before, tt.text)
+	}
+}
+
+func TestModelChangedFrom(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	m := NewModel(Config{Start: 11, End: 0, TimeInterval: time.Second, Decrement: 1, Clock: clock})
+	assert.Empty(t, m.changedFrom)
+
+	clock.Advance(time.Second)
+	m.advance()
+	assert.Equal(t, "11", m.changedFrom)
+
+	clock.Advance(time.Second / 2)
+	m.advance()
+	assert.Equal(t, "11", m.changedFrom, "The count before the last change should be kept until it changes again")
+}
+
+func TestGridRenderProfiles(t *testing.T) {
+	tests := []struct {
+		profile termenv.Profile
+		want    string
+	}{
+		{termenv.TrueColor, "\x1b[38;2;255;0;0m"},
+		{termenv.ANSI256, "\x1b[38;5;196m"},
+		{termenv.ANSI, "\x1b[91m"},
+		{termenv.Ascii, "╭───╮"},
+	}
+
+	for _, tt := range tests {
+		r := lipgloss.NewRenderer(io.Discard)
+		r.SetColorProfile(tt.profile)
+		g := fonts[DefaultFont].grid("1")
+		g[0][0].color = rainbow(0)
+		assert.Contains(t, g.render(r.NewStyle()), tt.want)
+	}
+}
@@ -0,0 +1,160 @@
+package countdown
+
+import "time"
+
+// engine computes the count from a fixed anchor instant rather than by
+// accumulating ticks, so the count at any moment is exact no matter how late
+// a tick is delivered. Step n falls due at anchor + n*interval.
+//
+// The anchor has its monotonic clock reading stripped so that elapsed time is
+// measured on the wall clock: time spent while the process is stopped or the
+// machine is asleep still counts.
+type engine struct {
+	start    int
+	end      int
+	step     int
+	interval time.Duration
+	anchor   time.Time
+	// offset is added to the count, from adjustments made while running.
+	offset int
+	// pausedAt is when the engine was paused, or zero while running.
+	pausedAt time.Time
+	// overtime carries the count on past the end instead of stopping there.
+	overtime bool
+	// endless counts up from the start without ever reaching the end.
+	endless bool
+}
+
+// newEngine returns an engine counting from start to end by step every
+// interval, beginning at now.
+func newEngine(start, end, step int, interval time.Duration, now time.Time) engine {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return engine{
+		start:    start,
+		end:      end,
+		step:     step,
+		interval: interval,
+		anchor:   now.Round(0),
+	}
+}
+
+// steps returns the number of whole intervals elapsed since the anchor. Time
+// stands still while paused.
+func (e engine) steps(now time.Time) int {
+	if e.paused() {
+		now = e.pausedAt
+	}
+	elapsed := now.Round(0).Sub(e.anchor)
+	if elapsed < 0 {
+		return 0
+	}
+	return int(elapsed / e.interval)
+}
+
+// elapsed returns the time counted since the anchor, not including time
+// spent paused.
+func (e engine) elapsed(now time.Time) time.Duration {
+	if e.paused() {
+		now = e.pausedAt
+	}
+	return max(now.Round(0).Sub(e.anchor), 0)
+}
+
+// base returns the count at now, ignoring any offset and the end.
+func (e engine) base(now time.Time) int {
+	if e.start > e.end && !e.endless {
+		return e.start - e.steps(now)*e.step
+	}
+	return e.start + e.steps(now)*e.step
+}
+
+// value returns the count at now, clamped to the end unless in overtime, and
+// whether the end has been reached.
+func (e engine) value(now time.Time) (int, bool) {
+	v := e.base(now) + e.offset
+	if e.endless {
+		return v, false
+	}
+	reached := v >= e.end
+	if e.start > e.end {
+		reached = v <= e.end
+	}
+	if reached && !e.overtime {
+		return e.end, true
+	}
+	return v, reached
+}
+
+// anchorOn anchors the engine on a deadline, so that its steps fall a whole
+// number of intervals before it and the count reaches the end there. The
+// start is rounded up to a whole number of steps from the end to match.
+func (e *engine) anchorOn(deadline time.Time) {
+	steps := max((e.start-e.end+e.step-1)/e.step, 0)
+	e.start = e.end + steps*e.step
+	e.anchor = deadline.Add(-time.Duration(steps) * e.interval).Round(0)
+}
+
+// endsAt returns when the count reaches the end, or reached it, while
+// running. Adjustments made so far are taken into account.
+func (e engine) endsAt() time.Time {
+	remaining := e.end - e.start - e.offset
+	if e.start > e.end {
+		remaining = -remaining
+	}
+	steps := max((remaining+e.step-1)/e.step, 0)
+	return e.anchor.Add(time.Duration(steps) * e.interval)
+}
+
+// untilNext returns how long to wait from now until the next step falls due.
+func (e engine) untilNext(now time.Time) time.Duration {
+	next := e.anchor.Add(time.Duration(e.steps(now)+1) * e.interval)
+	wait := next.Sub(now.Round(0))
+	if wait > e.interval {
+		// The wall clock was set back; don't stall for longer than a step
+		wait = e.interval
+	}
+	return wait
+}
+
+// paused reports whether the engine is paused.
+func (e engine) paused() bool {
+	return !e.pausedAt.IsZero()
+}
+
+// pause stops time for the engine at now.
+func (e *engine) pause(now time.Time) {
+	if !e.paused() {
+		e.pausedAt = now.Round(0)
+	}
+}
+
+// resume restarts time for the engine, moving the anchor later by the time
+// spent paused so that the count carries on from where it stopped.
+func (e *engine) resume(now time.Time) {
+	if e.paused() {
+		e.anchor = e.anchor.Add(now.Round(0).Sub(e.pausedAt))
+		e.pausedAt = time.Time{}
+	}
+}
+
+// adjust adds delta to the count.
+func (e *engine) adjust(delta int) {
+	e.offset += delta
+}
+
+// jumpTo sets the count at now to v, keeping the timing of steps.
+func (e *engine) jumpTo(v int, now time.Time) {
+	e.offset = v - e.base(now)
+}
+
+// restart begins the count again from the start at now, staying paused if it
+// was paused.
+func (e *engine) restart(now time.Time) {
+	e.anchor = now.Round(0)
+	e.offset = 0
+	if e.paused() {
+		e.pausedAt = e.anchor
+	}
+}
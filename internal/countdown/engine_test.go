@@ -0,0 +1,179 @@
+package countdown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngineValue(t *testing.T) {
+	anchor := time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		start    int
+		end      int
+		step     int
+		elapsed  time.Duration
+		want     int
+		wantDone bool
+	}{
+		{"at start", 10, 0, 1, 0, 10, false},
+		{"just before step", 10, 0, 1, 999 * time.Millisecond, 10, false},
+		{"on step", 10, 0, 1, time.Second, 9, false},
+		{"several steps", 10, 0, 2, 3500 * time.Millisecond, 4, false},
+		{"reaches end", 10, 0, 1, 10 * time.Second, 0, true},
+		{"overshoots end", 10, 0, 3, 4 * time.Second, 0, true},
+		{"counting up", 0, 10, 1, 4 * time.Second, 4, false},
+		{"counting up reaches end", 0, 10, 5, 2 * time.Second, 10, true},
+		{"clock before anchor", 10, 0, 1, -5 * time.Second, 10, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEngine(tt.start, tt.end, tt.step, time.Second, anchor)
+			got, done := e.value(anchor.Add(tt.elapsed))
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantDone, done)
+		})
+	}
+}
+
+func TestEngineOvertime(t *testing.T) {
+	anchor := time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC)
+	e := newEngine(10, 0, 1, time.Second, anchor)
+	e.overtime = true
+
+	got, done := e.value(anchor.Add(10 * time.Second))
+	assert.Equal(t, 0, got)
+	assert.True(t, done)
+
+	got, done = e.value(anchor.Add(13 * time.Second))
+	assert.Equal(t, -3, got, "Overtime should count on past the end")
+	assert.True(t, done)
+}
+
+func TestEngineUntilNext(t *testing.T) {
+	anchor := time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC)
+	e := newEngine(100, 0, 1, time.Second, anchor)
+
+	assert.Equal(t, time.Second, e.untilNext(anchor))
+	assert.Equal(t, 600*time.Millisecond, e.untilNext(anchor.Add(400*time.Millisecond)))
+	assert.Equal(t, 100*time.Millisecond, e.untilNext(anchor.Add(41900*time.Millisecond)))
+	assert.Equal(t, time.Second, e.untilNext(anchor.Add(-time.Hour)), "A clock set back should not stall ticks")
+}
+
+func TestEngineDoesNotDrift(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	start := clock.Now()
+	e := newEngine(3600, 0, 1, time.Second, start)
+
+	// Every tick is delivered 30ms late, as if processing took that long
+	for i := 0; i < 1000; i++ {
+		clock.Advance(e.untilNext(clock.Now()) + 30*time.Millisecond)
+	}
+
+	got, _ := e.value(clock.Now())
+	elapsed := clock.Now().Sub(start)
+	assert.Equal(t, 3600-int(elapsed/time.Second), got)
+	assert.Less(t, elapsed, 1001*time.Second, "Late ticks should not accumulate")
+}
+
+func TestEnginePauseAndResume(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	e := newEngine(100, 0, 1, time.Second, clock.Now())
+
+	clock.Advance(10500 * time.Millisecond)
+	e.pause(clock.Now())
+	assert.True(t, e.paused())
+
+	// Time stands still while paused
+	clock.Advance(time.Hour)
+	got, _ := e.value(clock.Now())
+	assert.Equal(t, 90, got)
+
+	// The count carries on from the same point within the step
+	e.resume(clock.Now())
+	assert.False(t, e.paused())
+	assert.Equal(t, 500*time.Millisecond, e.untilNext(clock.Now()))
+	clock.Advance(500 * time.Millisecond)
+	got, _ = e.value(clock.Now())
+	assert.Equal(t, 89, got)
+}
+
+func TestEngineAdjust(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	e := newEngine(60, 0, 1, time.Second, clock.Now())
+
+	clock.Advance(10 * time.Second)
+	e.adjust(30)
+	got, _ := e.value(clock.Now())
+	assert.Equal(t, 80, got)
+
+	e.adjust(-75)
+	got, done := e.value(clock.Now())
+	assert.Equal(t, 5, got)
+	assert.False(t, done)
+
+	e.adjust(-10)
+	got, done = e.value(clock.Now())
+	assert.Equal(t, 0, got)
+	assert.True(t, done, "Subtracting past the end should finish the countdown")
+}
+
+func TestEngineJumpToAndRestart(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	e := newEngine(0, 100, 2, time.Second, clock.Now())
+
+	clock.Advance(5 * time.Second)
+	e.jumpTo(90, clock.Now())
+	got, _ := e.value(clock.Now())
+	assert.Equal(t, 90, got)
+	clock.Advance(time.Second)
+	got, _ = e.value(clock.Now())
+	assert.Equal(t, 92, got)
+
+	e.pause(clock.Now())
+	e.restart(clock.Now())
+	clock.Advance(time.Minute)
+	got, _ = e.value(clock.Now())
+	assert.Equal(t, 0, got, "Restarting while paused should stay paused at the start")
+	assert.True(t, e.paused())
+}
+
+func TestEngineEndsAt(t *testing.T) {
+	anchor := time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC)
+
+	e := newEngine(10, 0, 3, time.Second, anchor)
+	assert.Equal(t, anchor.Add(4*time.Second), e.endsAt(), "A partial step should still be needed")
+
+	e.adjust(5)
+	assert.Equal(t, anchor.Add(5*time.Second), e.endsAt())
+
+	e = newEngine(0, 10, 1, time.Second, anchor)
+	e.pause(anchor.Add(2 * time.Second))
+	e.resume(anchor.Add(5 * time.Second))
+	assert.Equal(t, anchor.Add(13*time.Second), e.endsAt(), "Time spent paused should not count")
+}
+
+func TestEngineAnchorOn(t *testing.T) {
+	now := time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC)
+	deadline := now.Add(5 * time.Second)
+
+	e := newEngine(5, 0, 2, 2*time.Second, now)
+	e.anchorOn(deadline)
+	assert.Equal(t, 6, e.start, "The start should be rounded up to whole steps")
+	assert.Equal(t, deadline.Add(-6*time.Second), e.anchor)
+	assert.Equal(t, deadline, e.endsAt())
+
+	got, _ := e.value(now)
+	assert.Equal(t, 6, got)
+	got, _ = e.value(now.Add(time.Second))
+	assert.Equal(t, 4, got, "Steps should fall whole intervals before the deadline")
+
+	e = newEngine(0, 0, 1, time.Second, now)
+	e.anchorOn(now.Add(-time.Second))
+	_, done := e.value(now)
+	assert.True(t, done, "A deadline which has passed should end at once")
+}
@@ -0,0 +1,128 @@
+package countdown
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"time"
+)
+
+// EventSchemaVersion is the version of the Event schema. It is increased when
+// a field is removed or changes meaning; new fields and event types may be
+// added without changing it.
+const EventSchemaVersion = 1
+
+// EventType names something which happened to the countdown.
+type EventType string
+
+const (
+	// EventStart is sent once, when the countdown begins.
+	EventStart EventType = "start"
+	// EventTick is sent each time the count steps.
+	EventTick EventType = "tick"
+	// EventFinalPhaseEntered is sent when the count reaches the final phase.
+	EventFinalPhaseEntered EventType = "final_phase_entered"
+	// EventPaused is sent when the countdown is paused.
+	EventPaused EventType = "paused"
+	// EventResumed is sent when a paused countdown carries on.
+	EventResumed EventType = "resumed"
+	// EventDone is sent when the count reaches its end.
+	EventDone EventType = "done"
+	// EventOvertimeEnded is sent when overtime ends, by quitting or on
+	// reaching Config.OvertimeMax. The countdown has completed.
+	EventOvertimeEnded EventType = "overtime_ended"
+	// EventAborted is sent when the user quits before the end.
+	EventAborted EventType = "aborted"
+	// EventKilled is sent when a shutdown signal ends the countdown.
+	EventKilled EventType = "killed"
+	// EventStopped is sent when the countdown is ended early by a StopMsg.
+	EventStopped EventType = "stopped"
+	// EventLap is sent when a lap is recorded with Config.Endless.
+	EventLap EventType = "lap"
+	// EventSegment is sent when the next segment of a Config.Sequence
+	// begins.
+	EventSegment EventType = "segment"
+)
+
+// Event is one entry in the event stream, written as a line of JSON such as:
+//
+//	{"version":1,"type":"tick","time":"2026-01-02T15:04:05.5Z","current":4.5,"remaining":4.5,"elapsed":5.5,"percent":55}
+type Event struct {
+	// Version is the schema version, EventSchemaVersion.
+	Version int `json:"version"`
+	// Type is what happened.
+	Type EventType `json:"type"`
+	// Time is when it happened.
+	Time time.Time `json:"time"`
+	// Current is the count, with the decimal places set by Config.Precision.
+	Current float64 `json:"current"`
+	// Remaining is how far the count has left to go to the end.
+	Remaining float64 `json:"remaining"`
+	// Overtime is how far the count has gone past the end, with
+	// Config.Overtime.
+	Overtime float64 `json:"overtime,omitempty"`
+	// Elapsed is the number of seconds counted so far, not including time
+	// spent paused.
+	Elapsed float64 `json:"elapsed"`
+	// Percent is how far the count has got from the start to the end, from
+	// 0 to 100.
+	Percent float64 `json:"percent"`
+	// Signal is the name of the signal received, for killed events.
+	Signal string `json:"signal,omitempty"`
+	// Segment is the label of the segment being counted down, and
+	// SegmentNumber its position from 1, with Config.Sequence. Current,
+	// Remaining, Elapsed and Percent are for the segment alone.
+	Segment       string `json:"segment,omitempty"`
+	SegmentNumber int    `json:"segment_number,omitempty"`
+}
+
+// JSONEvents returns a function for Config.OnEvent which writes each event to
+// w as a line of JSON. Write errors are ignored so that a closed pipe doesn't
+// interrupt the countdown.
+func JSONEvents(w io.Writer) func(Event) {
+	enc := json.NewEncoder(w)
+	return func(e Event) {
+		_ = enc.Encode(e)
+	}
+}
+
+// emit sends an event describing the current state to Config.OnEvent.
+func (m Model) emit(t EventType) {
+	if m.config.OnEvent == nil {
+		return
+	}
+
+	now := m.clock.Now()
+	scale := float64(Scale(m.config.Precision))
+	e := Event{
+		Version:   EventSchemaVersion,
+		Type:      t,
+		Time:      now,
+		Current:   float64(m.current) / scale,
+		Remaining: float64(abs(m.config.End-m.current)) / scale,
+		Elapsed:   math.Round(m.engine.elapsed(now).Seconds()*1000) / 1000,
+		Percent:   math.Round(m.percent()*10000) / 100,
+	}
+	if m.overtime {
+		e.Overtime, e.Remaining = e.Remaining, 0
+	}
+	if m.config.Endless {
+		// There is no end to measure against
+		e.Remaining = 0
+	}
+	if m.inSequence() {
+		e.Segment, e.SegmentNumber = m.base.Sequence[m.segment].Label, m.segment+1
+	}
+	if t == EventKilled && m.signal != nil {
+		e.Signal = m.signal.String()
+	}
+	m.config.OnEvent(e)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,124 @@
+package countdown
+
+import (
+	"bytes"
+	"encoding/json"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsFromRun(t *testing.T) {
+	var events []Event
+	cfg := Config{
+		Start:        4,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		FinalPhase:   2,
+		OnEvent:      func(e Event) { events = append(events, e) },
+	}
+
+	r := startPlain(t, cfg)
+	for range 4 {
+		r.step(time.Second)
+	}
+	<-r.result
+
+	assert.Equal(t, []EventType{
+		EventStart, EventTick, EventFinalPhaseEntered, EventTick, EventTick, EventDone,
+	}, eventTypes(events))
+
+	tests := []struct {
+		index     int
+		current   float64
+		remaining float64
+		elapsed   float64
+		percent   float64
+	}{
+		{0, 4, 4, 0, 0},
+		{1, 3, 3, 1, 25},
+		{2, 2, 2, 2, 50},
+		{5, 0, 0, 4, 100},
+	}
+	for _, tt := range tests {
+		e := events[tt.index]
+		assert.Equal(t, EventSchemaVersion, e.Version)
+		assert.Equal(t, tt.current, e.Current, "current of event %d", tt.index)
+		assert.Equal(t, tt.remaining, e.Remaining, "remaining of event %d", tt.index)
+		assert.Equal(t, tt.elapsed, e.Elapsed, "elapsed of event %d", tt.index)
+		assert.Equal(t, tt.percent, e.Percent, "percent of event %d", tt.index)
+	}
+}
+
+func TestEventsFromControls(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	var events []Event
+	m := NewModel(Config{
+		Start:        50,
+		End:          0,
+		TimeInterval: 100 * time.Millisecond,
+		Decrement:    1,
+		Precision:    1,
+		Clock:        clock,
+		OnSignal:     SignalExit,
+		OnEvent:      func(e Event) { events = append(events, e) },
+	})
+
+	clock.Advance(time.Second)
+	m, _ = pressKey(m, " ")
+	clock.Advance(time.Minute)
+	m, _ = pressKey(m, " ")
+	updated, _ := m.Update(shutdownMsg{signal: syscall.SIGTERM})
+	m = updated.(Model)
+
+	require.Len(t, events, 3)
+	assert.Equal(t, EventPaused, events[0].Type)
+	assert.Equal(t, 4.0, events[0].Current)
+	assert.Equal(t, 1.0, events[0].Elapsed)
+	assert.Equal(t, EventResumed, events[1].Type)
+	assert.Equal(t, 1.0, events[1].Elapsed, "Time spent paused should not count as elapsed")
+	assert.Equal(t, EventKilled, events[2].Type)
+	assert.Equal(t, "terminated", events[2].Signal)
+
+	events = nil
+	m, _ = pressKey(m, "q")
+	require.Len(t, events, 1)
+	assert.Equal(t, EventAborted, events[0].Type)
+}
+
+func TestJSONEvents(t *testing.T) {
+	var buf bytes.Buffer
+	write := JSONEvents(&buf)
+	write(Event{
+		Version: EventSchemaVersion,
+		Type:    EventTick,
+		Time:    time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC),
+		Current: 4.5,
+		Percent: 55,
+	})
+	write(Event{Version: EventSchemaVersion, Type: EventKilled, Signal: "interrupt"})
+
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	require.Len(t, lines, 2, "Each event should be written on its own line")
+	assert.JSONEq(t,
+		`{"version":1,"type":"tick","time":"2026-06-15T10:00:00Z","current":4.5,"remaining":0,"elapsed":0,"percent":55}`,
+		string(lines[0]))
+
+	var e Event
+	require.NoError(t, json.Unmarshal(lines[1], &e))
+	assert.Equal(t, EventKilled, e.Type)
+	assert.Equal(t, "interrupt", e.Signal)
+}
+
+// eventTypes returns the type of each event.
+func eventTypes(events []Event) []EventType {
+	types := make([]EventType, len(events))
+	for i, e := range events {
+		types[i] = e.Type
+	}
+	return types
+}
@@ -0,0 +1,412 @@
+package countdown
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/countdown/countdown/internal/figlet"
+)
+
+// Font is a set of glyphs for drawing the count in big characters. Every
+// built-in font has glyphs for the digits, colon, decimal point, plus and
+// minus signs and space, so it can draw any count.
+type Font struct {
+	Name string
+	// Height is the number of lines in every glyph.
+	Height int
+	// Glyphs holds the lines of each character's glyph, which are all the
+	// same width and include any space between it and the next.
+	Glyphs map[rune][]string
+
+	// figlet joins the glyphs by its layout, for a font loaded from a FIGlet
+	// file.
+	figlet *figlet.Font
+}
+
+// DefaultFont is the name of the font used when none is given.
+const DefaultFont = "rounded"
+
+// fontRunes are the characters every built-in font draws.
+const fontRunes = "0123456789:.+- "
+
+// bigDigits are the glyphs of the rounded font, the default, drawn with
+// rounded box corners.
+var bigDigits = map[rune][]string{
+	'0': {
+		"╭───────╮",
+		"│ ╭───╮ │",
+		"│ │   │ │",
+		"│ │   │ │",
+		"│ ╰───╯ │",
+		"╰───────╯",
+	},
+	'1': {
+		"╭───╮",
+		"╰─╮ │",
+		"  │ │",
+		"  │ │",
+		"  │ │",
+		"  ╰─╯",
+	},
+	'2': {
+		"╭───────╮",
+		"╰─────╮ │",
+		"╭─────╯ │",
+		"│ ╭─────╯",
+		"│ ╰─────╮",
+		"╰───────╯",
+	},
+	'3': {
+		"╭───────╮",
+		"╰─────╮ │",
+		"╭─────╯ │",
+		"╰─────╮ │",
+		"╭─────╯ │",
+		"╰───────╯",
+	},
+	'4': {
+		"╭─╮  ╭─╮",
+		"│ │  │ │",
+		"│ ╰──╯ │",
+		"╰────╮ │",
+		"     │ │",
+		"     ╰─╯",
+	},
+	'5': {
+		"╭───────╮",
+		"│ ╭─────╯",
+		"│ ╰─────╮",
+		"╰─────╮ │",
+		"╭─────╯ │",
+		"╰───────╯",
+	},
+	'6': {
+		"╭───────╮",
+		"│ ╭─────╯",
+		"│ ╰─────╮",
+		"│ ╭───╮ │",
+		"│ ╰───╯ │",
+		"╰───────╯",
+	},
+	'7': {
+		"╭─────╮",
+		"╰───╮ │",
+		"    │ │",
+		"    │ │",
+		"    │ │",
+		"    ╰─╯",
+	},
+	'8': {
+		"╭───────╮",
+		"│ ╭───╮ │",
+		"│ ╰───╯ │",
+		"│ ╭───╮ │",
+		"│ ╰───╯ │",
+		"╰───────╯",
+	},
+	'9': {
+		"╭───────╮",
+		"│ ╭───╮ │",
+		"│ ╰───╯ │",
+		"╰─────╮ │",
+		"╭─────╯ │",
+		"╰───────╯",
+	},
+	':': {
+		"   ",
+		"╭─╮",
+		"╰─╯",
+		"╭─╮",
+		"╰─╯",
+		"   ",
+	},
+	'.': {
+		"   ",
+		"   ",
+		"   ",
+		"   ",
+		"╭─╮",
+		"╰─╯",
+	},
+	'+': {
+		"         ",
+		"   ╭─╮   ",
+		"╭──╯ ╰──╮",
+		"╰──╮ ╭──╯",
+		"   ╰─╯   ",
+		"         ",
+	},
+	'-': {
+		"       ",
+		"       ",
+		"╭─────╮",
+		"╰─────╯",
+		"       ",
+		"       ",
+	},
+	' ': {
+		"   ",
+		"   ",
+		"   ",
+		"   ",
+		"   ",
+		"   ",
+	},
+}
+
+// sevenSegments lists the lit segments of each character on a seven-segment
+// display: a at the top, then b to f clockwise, and g in the middle.
+var sevenSegments = map[rune]string{
+	'0': "abcdef",
+	'1': "bc",
+	'2': "abdeg",
+	'3': "abcdg",
+	'4': "bcfg",
+	'5': "acdfg",
+	'6': "acdefg",
+	'7': "abc",
+	'8': "abcdefg",
+	'9': "abcdfg",
+	'-': "g",
+}
+
+// sevenSegmentGlyphs draws each character of sevenSegments five lines high
+// with heavy lines, adding the rest of fontRunes.
+func sevenSegmentGlyphs() map[rune][]string {
+	glyphs := map[rune][]string{
+		':': {"  ", "• ", "  ", "• ", "  "},
+		'.': {"  ", "  ", "  ", "  ", "• "},
+		'+': {"    ", " ┃  ", "━╋━ ", " ┃  ", "    "},
+		' ': {"  ", "  ", "  ", "  ", "  "},
+	}
+	for r, lit := range sevenSegments {
+		on := func(segment rune, s string) string {
+			if strings.ContainsRune(lit, segment) {
+				return s
+			}
+			return strings.Repeat(" ", lipgloss.Width(s))
+		}
+		glyphs[r] = []string{
+			" " + on('a', "━━") + "  ",
+			on('f', "┃") + "  " + on('b', "┃") + " ",
+			" " + on('g', "━━") + "  ",
+			on('e', "┃") + "  " + on('c', "┃") + " ",
+			" " + on('d', "━━") + "  ",
+		}
+	}
+	return glyphs
+}
+
+// smallGlyphs draws each character of sevenSegments three lines high in
+// ASCII, adding the rest of fontRunes.
+func smallGlyphs() map[rune][]string {
+	glyphs := map[rune][]string{
+		':': {"  ", ". ", ". "},
+		'.': {"  ", "  ", ". "},
+		'+': {"    ", "_|_ ", " |  "},
+		' ': {"  ", "  ", "  "},
+	}
+	for r, lit := range sevenSegments {
+		on := func(segment rune, s string) string {
+			if strings.ContainsRune(lit, segment) {
+				return s
+			}
+			return " "
+		}
+		glyphs[r] = []string{
+			" " + on('a', "_") + "  ",
+			on('f', "|") + on('g', "_") + on('b', "|") + " ",
+			on('e', "|") + on('d', "_") + on('c', "|") + " ",
+		}
+	}
+	return glyphs
+}
+
+// pixels are bitmaps of fontRunes, three pixels wide and five high, from
+// which the block and braille fonts are drawn.
+var pixels = map[rune][]string{
+	'0': {"###", "# #", "# #", "# #", "###"},
+	'1': {" # ", "## ", " # ", " # ", "###"},
+	'2': {"###", "  #", "###", "#  ", "###"},
+	'3': {"###", "  #", "###", "  #", "###"},
+	'4': {"# #", "# #", "###", "  #", "  #"},
+	'5': {"###", "#  ", "###", "  #", "###"},
+	'6': {"###", "#  ", "###", "# #", "###"},
+	'7': {"###", "  #", "  #", "  #", "  #"},
+	'8': {"###", "# #", "###", "# #", "###"},
+	'9': {"###", "# #", "###", "  #", "###"},
+	':': {" ", "#", " ", "#", " "},
+	'.': {" ", " ", " ", " ", "#"},
+	'+': {"   ", " # ", "###", " # ", "   "},
+	'-': {"   ", "   ", "###", "   ", "   "},
+	' ': {"  ", "  ", "  ", "  ", "  "},
+}
+
+// blockGlyphs draws pixels as solid blocks, each two cells wide to keep
+// them square.
+func blockGlyphs() map[rune][]string {
+	glyphs := map[rune][]string{}
+	for r, rows := range pixels {
+		lines := make([]string, len(rows))
+		for i, row := range rows {
+			line := strings.NewReplacer("#", "██", " ", "  ").Replace(row)
+			lines[i] = line + " "
+		}
+		glyphs[r] = lines
+	}
+	return glyphs
+}
+
+// brailleDots are the bits of the braille dots in a cell, by row and then
+// column.
+var brailleDots = [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+// brailleGlyphs draws pixels as braille dots, two by four to a cell, so
+// that a digit takes two cells by two lines. The pixels are moved down a row
+// to sit in the middle of the eight rows of dots.
+func brailleGlyphs() map[rune][]string {
+	glyphs := map[rune][]string{}
+	for r, rows := range pixels {
+		// A column of dots is left after each glyph
+		cells := (len(rows[0]) + 2) / 2
+		lines := make([]string, 2)
+		for line := range lines {
+			var b strings.Builder
+			for cell := range cells {
+				dots := rune(0x2800)
+				for dy := range 4 {
+					y := line*4 + dy - 1
+					for dx := range 2 {
+						x := cell*2 + dx
+						if y >= 0 && y < len(rows) && x < len(rows[y]) && rows[y][x] == '#' {
+							dots |= brailleDots[dy][dx]
+						}
+					}
+				}
+				b.WriteRune(dots)
+			}
+			lines[line] = b.String()
+		}
+		glyphs[r] = lines
+	}
+	return glyphs
+}
+
+// doubleGlyphs redraws bigDigits with double lines.
+func doubleGlyphs() map[rune][]string {
+	r := strings.NewReplacer("╭", "╔", "╮", "╗", "╰", "╚", "╯", "╝", "─", "═", "│", "║")
+	glyphs := map[rune][]string{}
+	for c, lines := range bigDigits {
+		glyphs[c] = make([]string, len(lines))
+		for i, line := range lines {
+			glyphs[c][i] = r.Replace(line)
+		}
+	}
+	return glyphs
+}
+
+// fonts are the built-in fonts, by name.
+var fonts = map[string]Font{
+	"rounded":       {Name: "rounded", Height: 6, Glyphs: bigDigits},
+	"double":        {Name: "double", Height: 6, Glyphs: doubleGlyphs()},
+	"seven-segment": {Name: "seven-segment", Height: 5, Glyphs: sevenSegmentGlyphs()},
+	"block":         {Name: "block", Height: 5, Glyphs: blockGlyphs()},
+	"small":         {Name: "small", Height: 3, Glyphs: smallGlyphs()},
+	"braille":       {Name: "braille", Height: 2, Glyphs: brailleGlyphs()},
+}
+
+// LookupFont returns the built-in font with the given name.
+func LookupFont(name string) (Font, bool) {
+	f, ok := fonts[name]
+	return f, ok
+}
+
+// FontNames returns the names of the built-in fonts in order.
+func FontNames() []string {
+	return slices.Sorted(maps.Keys(fonts))
+}
+
+// FIGletFont returns a font drawing with a FIGlet font's glyphs, joined by
+// its layout. Characters of the count which it has no glyph for are drawn as
+// they are, halfway up to its baseline or, for the decimal point, on it.
+func FIGletFont(name string, ff *figlet.Font) Font {
+	copied := *ff
+	ff = &copied
+	ff.Glyphs = maps.Clone(ff.Glyphs)
+	for _, r := range fontRunes {
+		if _, ok := ff.Glyphs[r]; ok {
+			continue
+		}
+		glyph := slices.Repeat([]string{" "}, ff.Height)
+		row := ff.Baseline / 2
+		if r == '.' {
+			row = ff.Baseline - 1
+		}
+		glyph[row] = string(r)
+		ff.Glyphs[r] = glyph
+	}
+	return Font{Name: name, Height: ff.Height, Glyphs: ff.Glyphs, figlet: ff}
+}
+
+// GlyphWidth returns the width of the character's glyph, or 0 if the font
+// has none.
+func (f Font) GlyphWidth(r rune) int {
+	lines, ok := f.Glyphs[r]
+	if !ok || len(lines) == 0 {
+		return 0
+	}
+	return lipgloss.Width(lines[0])
+}
+
+// Width returns the width of text drawn in the font.
+func (f Font) Width(text string) int {
+	if f.figlet != nil {
+		return lipgloss.Width(f.Render(text))
+	}
+	width := 0
+	for _, r := range text {
+		width += f.GlyphWidth(r)
+	}
+	return width
+}
+
+// Render draws text in the font, skipping characters which have no glyph.
+func (f Font) Render(text string) string {
+	if f.figlet != nil {
+		return f.figlet.Render(text)
+	}
+	lines := make([]strings.Builder, f.Height)
+	drawn := false
+	for _, r := range text {
+		glyph, ok := f.Glyphs[r]
+		if !ok {
+			continue
+		}
+		for i := range lines {
+			lines[i].WriteString(glyph[i])
+		}
+		drawn = true
+	}
+	if !drawn {
+		return ""
+	}
+
+	rendered := make([]string, len(lines))
+	for i := range lines {
+		rendered[i] = lines[i].String()
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// renderBigNumber renders a number as large ASCII art digits.
+func renderBigNumber(num int) string {
+	return renderBigText(strconv.Itoa(num))
+}
+
+// renderBigText renders text in the default font.
+func renderBigText(text string) string {
+	return fonts[DefaultFont].Render(text)
+}
@@ -0,0 +1,98 @@
+package countdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/countdown/countdown/internal/figlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFontGlyphs(t *testing.T) {
+	for _, name := range FontNames() {
+		t.Run(name, func(t *testing.T) {
+			font, ok := LookupFont(name)
+			require.True(t, ok)
+			assert.Equal(t, name, font.Name)
+			for _, r := range fontRunes {
+				glyph, ok := font.Glyphs[r]
+				if !assert.True(t, ok, "The font should have a glyph for %q", r) {
+					continue
+				}
+				assert.Len(t, glyph, font.Height, "The glyph for %q should be the font's height", r)
+				for _, line := range glyph {
+					assert.Equal(t, font.GlyphWidth(r), lipgloss.Width(line), "Every line of the glyph for %q should be the same width", r)
+				}
+			}
+		})
+	}
+}
+
+func TestFontRender(t *testing.T) {
+	tests := []struct {
+		name  string
+		font  string
+		text  string
+		lines []string
+	}{
+		{"small", "small", "-1.5", []string{"           _  ", " _    |   |_  ", "      | .  _| "}},
+		{"seven-segment", "seven-segment", "7", []string{" ━━  ", "   ┃ ", "     ", "   ┃ ", "     "}},
+		{"braille", "braille", "1", []string{"⢴⠀", "⠚⠂"}},
+		{"block", "block", "-", []string{"       ", "       ", "██████ ", "       ", "       "}},
+		{"double", "double", ":", []string{"   ", "╔═╗", "╚═╝", "╔═╗", "╚═╝", "   "}},
+		{"unknown characters skipped", "small", "1x", []string{"    ", "  | ", "  | "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			font, _ := LookupFont(tt.font)
+			assert.Equal(t, tt.lines, strings.Split(font.Render(tt.text), "\n"))
+			assert.Equal(t, lipgloss.Width(tt.lines[0]), font.Width(tt.text))
+		})
+	}
+
+	font, _ := LookupFont("small")
+	assert.Empty(t, font.Render("x"), "Nothing should be drawn without a glyph")
+}
+
+func TestModelFont(t *testing.T) {
+	font, _ := LookupFont("small")
+	m := NewModel(Config{SpinnerType: "none", Title: "Liftoff in", Start: 10, End: 0, Big: true, Font: font})
+	lines := strings.Split(m.View(), "\n")
+	require.Len(t, lines, 4, "The count should be drawn three lines high beneath the title")
+	assert.Equal(t, "  | | |", strings.TrimRight(lines[2], " "))
+
+	m = NewModel(Config{SpinnerType: "none", Title: "Liftoff in", Start: 10, End: 0, Big: true})
+	assert.Len(t, strings.Split(m.View(), "\n"), 7, "The default font should be used without one")
+}
+
+func TestFIGletFont(t *testing.T) {
+	// Digits one and two, three lines high, in a font which smushes equal
+	// characters, so that the |s where they meet become one
+	var src strings.Builder
+	src.WriteString("flf2a$ 3 2 4 1 0\n")
+	for r := ' '; r <= '2'; r++ {
+		switch r {
+		case '1':
+			src.WriteString(" |@\n |@\n |@@\n")
+		case '2':
+			src.WriteString("|_ @\n _|@\n|_ @@\n")
+		default:
+			src.WriteString("$@\n$@\n$@@\n")
+		}
+	}
+	ff, err := figlet.Parse("digits.flf", []byte(src.String()))
+	require.NoError(t, err)
+
+	font := FIGletFont("digits", ff)
+	assert.Equal(t, 3, font.Height)
+	assert.Equal(t, figlet.Smushed, ff.Layout)
+	assert.Equal(t, []string{" |_ ", " |_|", " |_ "}, strings.Split(font.Render("12"), "\n"))
+	assert.Equal(t, 4, font.Width("12"))
+
+	assert.Equal(t, []string{" ", ":", " "}, font.Glyphs[':'], "A missing glyph should be drawn halfway up to the baseline")
+	assert.NotContains(t, ff.Glyphs, ':', "The FIGlet font should be left as it is")
+	assert.Equal(t, []string{" | ", " |:", " | "}, strings.Split(font.Render("1:"), "\n"))
+}
@@ -0,0 +1,85 @@
+package countdown
+
+import "github.com/charmbracelet/bubbles/key"
+
+// keyMap defines the key bindings for controlling a running countdown.
+type keyMap struct {
+	Pause    key.Binding
+	Add      key.Binding
+	Subtract key.Binding
+	Restart  key.Binding
+	Skip     key.Binding
+	Lap      key.Binding
+	Next     key.Binding
+	Previous key.Binding
+	Help     key.Binding
+	Quit     key.Binding
+}
+
+// defaultKeyMap returns the default key bindings. Counting endlessly there is
+// no final phase to skip to, and laps can be recorded instead. Moving between
+// segments is only enabled for a sequence.
+func defaultKeyMap(endless bool) keyMap {
+	k := keyMap{
+		Pause: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "pause/resume"),
+		),
+		Add: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", "add to count"),
+		),
+		Subtract: key.NewBinding(
+			key.WithKeys("-", "_"),
+			key.WithHelp("-", "subtract from count"),
+		),
+		Restart: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "restart"),
+		),
+		Skip: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "skip to final phase"),
+		),
+		Lap: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "record lap"),
+		),
+		Next: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next segment"),
+			key.WithDisabled(),
+		),
+		Previous: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "previous segment"),
+			key.WithDisabled(),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "esc", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+	k.Skip.SetEnabled(!endless)
+	k.Lap.SetEnabled(endless)
+	return k
+}
+
+// ShortHelp returns the bindings shown in the short help view.
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Pause, k.Help, k.Quit}
+}
+
+// FullHelp returns the bindings shown in the full help view.
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Pause, k.Restart, k.Skip, k.Lap},
+		{k.Add, k.Subtract},
+		{k.Next, k.Previous},
+		{k.Help, k.Quit},
+	}
+}
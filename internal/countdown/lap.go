@@ -0,0 +1,47 @@
+package countdown
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// Lap is a point recorded with the lap key, as displayed.
+type Lap struct {
+	// Count is the count when the lap was recorded.
+	Count string
+	// Split is how far the count moved since the previous lap, or since the
+	// start for the first.
+	Split string
+}
+
+// lap records a lap at the current count.
+func (m *Model) lap() {
+	from := m.config.Start
+	if len(m.lapCounts) > 0 {
+		from = m.lapCounts[len(m.lapCounts)-1]
+	}
+	m.lapCounts = append(m.lapCounts, m.current)
+	m.laps = append(m.laps, Lap{
+		Count: m.formatValue(m.current),
+		Split: m.formatValue(abs(m.current - from)),
+	})
+	m.emit(EventLap)
+}
+
+// lapTable renders the laps as a table with the most recent last, or "" if
+// there are none.
+func (m Model) lapTable() string {
+	if len(m.laps) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Lap\tSplit\tTotal")
+	for i, lap := range m.laps {
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", i+1, lap.Split, lap.Count)
+	}
+	_ = tw.Flush()
+	return m.titleStyle.Render(strings.TrimRight(b.String(), "\n"))
+}
@@ -0,0 +1,74 @@
+package countdown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelEndless(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	var events []Event
+	m := NewModel(Config{
+		SpinnerType:  "none",
+		Title:        "Elapsed",
+		Start:        0,
+		End:          100,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		FinalPhase:   3,
+		Format:       FormatClock,
+		Clock:        clock,
+		Endless:      true,
+		OnEvent:      func(e Event) { events = append(events, e) },
+	})
+	tick := func(d time.Duration) {
+		clock.Advance(d)
+		updated, _ := m.Update(tickMsg{tag: m.tickTag})
+		m = updated.(Model)
+	}
+
+	tick(90 * time.Second)
+	assert.False(t, m.done)
+	assert.Equal(t, -1, m.stage, "Counting endlessly there should be no final phase")
+	assert.Equal(t, "Elapsed 00:01:30", m.PlainView())
+
+	m, _ = pressKey(m, "s")
+	assert.Equal(t, 90, m.current, "Skip should do nothing without a final phase")
+
+	m, _ = pressKey(m, "l")
+	tick(45 * time.Second)
+	m, _ = pressKey(m, "l")
+	assert.Equal(t, []Lap{
+		{Count: "00:01:30", Split: "00:01:30"},
+		{Count: "00:02:15", Split: "00:00:45"},
+	}, m.laps)
+	assert.Contains(t, m.View(), "00:00:45")
+
+	last := events[len(events)-1]
+	assert.Equal(t, EventLap, last.Type)
+	assert.Equal(t, 135.0, last.Current)
+	assert.Equal(t, 0.0, last.Remaining)
+	assert.Equal(t, 0.0, last.Percent)
+
+	tick(time.Hour)
+	assert.False(t, m.done, "The count should carry on past the end")
+	m, _ = pressKey(m, "q")
+	result := m.result()
+	assert.Equal(t, Aborted, result.Outcome)
+	assert.Equal(t, "01:02:15", result.Count)
+	require.Len(t, result.Laps, 2)
+
+	updated, _ := m.restart()
+	m = updated.(Model)
+	assert.Empty(t, m.laps, "Restarting should clear the laps")
+}
+
+func TestLapKeyOnlyWhenEndless(t *testing.T) {
+	m := NewModel(Config{Start: 10, End: 0, TimeInterval: time.Second, Decrement: 1})
+	m, _ = pressKey(m, "l")
+	assert.Empty(t, m.laps)
+	assert.Empty(t, m.lapTable())
+}
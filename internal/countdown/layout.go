@@ -0,0 +1,29 @@
+package countdown
+
+import "github.com/charmbracelet/lipgloss"
+
+// place positions the rendered view within the terminal by Config.Align,
+// and by Config.VAlign in fullscreen, once the terminal's size is known.
+func (m Model) place(view string) string {
+	height := lipgloss.Height(view)
+	if m.config.Fullscreen && m.height > 0 {
+		height = m.height
+	}
+	if m.width == 0 || (m.config.Align == lipgloss.Left && height == lipgloss.Height(view)) {
+		return view
+	}
+	return lipgloss.Place(m.width, height, m.config.Align, m.config.VAlign, view)
+}
+
+// bigFits reports whether the count fits the terminal in big digits, along
+// with the title line above them, once the terminal's size is known.
+func (m Model) bigFits() bool {
+	if m.width == 0 {
+		return true
+	}
+	big := m.config.Font.Render(m.formatCount())
+	if lipgloss.Width(big) > m.width-m.containerStyle.GetHorizontalFrameSize() {
+		return false
+	}
+	return m.height == 0 || lipgloss.Height(big)+1 <= m.height-m.containerStyle.GetVerticalFrameSize()
+}
@@ -0,0 +1,59 @@
+package countdown
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resize sends the model a new terminal size.
+func resize(m Model, width, height int) Model {
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return updated.(Model)
+}
+
+func TestViewAlign(t *testing.T) {
+	cfg := Config{SpinnerType: "none", Title: "Liftoff in", Start: 10, End: 0}
+
+	m := resize(NewModel(cfg), 40, 10)
+	assert.Equal(t, " Liftoff in 10", m.View(), "Left alignment should leave the view as it is")
+
+	cfg.Align = lipgloss.Center
+	m = resize(NewModel(cfg), 40, 10)
+	lines := strings.Split(m.View(), "\n")
+	require.Len(t, lines, 1, "Vertical alignment should only apply in fullscreen")
+	assert.Equal(t, 40, lipgloss.Width(lines[0]))
+	assert.True(t, strings.HasPrefix(lines[0], strings.Repeat(" ", 14)+"Liftoff in 10"))
+
+	cfg.Align = lipgloss.Right
+	m = resize(NewModel(cfg), 40, 10)
+	assert.True(t, strings.HasSuffix(m.View(), "Liftoff in 10"))
+
+	cfg.Fullscreen = true
+	cfg.VAlign = lipgloss.Bottom
+	m = resize(NewModel(cfg), 40, 10)
+	lines = strings.Split(m.View(), "\n")
+	require.Len(t, lines, 10, "Fullscreen should fill the terminal")
+	assert.True(t, strings.HasSuffix(lines[9], "Liftoff in 10"))
+
+	m = NewModel(cfg)
+	assert.Equal(t, " Liftoff in 10", m.View(), "Nothing should move before the size is known")
+}
+
+func TestViewBigFallback(t *testing.T) {
+	m := NewModel(Config{SpinnerType: "none", Title: "Liftoff in", Start: 100, End: 0, Big: true})
+	assert.Len(t, strings.Split(m.View(), "\n"), 7)
+
+	m = resize(m, 80, 24)
+	assert.Len(t, strings.Split(m.View(), "\n"), 7, "Big digits should be used when they fit")
+
+	m = resize(m, 20, 24)
+	assert.Equal(t, " Liftoff in 100", m.View(), "Big digits too wide for the terminal should fall back to the number")
+
+	m = resize(m, 80, 5)
+	assert.Equal(t, " Liftoff in 100", m.View(), "Big digits too tall for the terminal should fall back to the number")
+}
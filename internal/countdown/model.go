@@ -1,111 +1,47 @@
 package countdown
 
 import (
+	"cmp"
 	"fmt"
+	"io"
 	"math"
 	"os"
-	"os/signal"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-// bigDigits contains ASCII art representations of digits 0-9 and colon.
-var bigDigits = map[rune][]string{
-	'0': {
-		"╭───────╮",
-		"│ ╭───╮ │",
-		"│ │   │ │",
-		"│ │   │ │",
-		"│ ╰───╯ │",
-		"╰───────╯",
-	},
-	'1': {
-		"╭───╮",
-		"╰─╮ │",
-		"  │ │",
-		"  │ │",
-		"  │ │",
-		"  ╰─╯",
-	},
-	'2': {
-		"╭───────╮",
-		"╰─────╮ │",
-		"╭─────╯ │",
-		"│ ╭─────╯",
-		"│ ╰─────╮",
-		"╰───────╯",
-	},
-	'3': {
-		"╭───────╮",
-		"╰─────╮ │",
-		"╭─────╯ │",
-		"╰─────╮ │",
-		"╭─────╯ │",
-		"╰───────╯",
-	},
-	'4': {
-		"╭─╮  ╭─╮",
-		"│ │  │ │",
-		"│ ╰──╯ │",
-		"╰────╮ │",
-		"     │ │",
-		"     ╰─╯",
-	},
-	'5': {
-		"╭───────╮",
-		"│ ╭─────╯",
-		"│ ╰─────╮",
-		"╰─────╮ │",
-		"╭─────╯ │",
-		"╰───────╯",
-	},
-	'6': {
-		"╭───────╮",
-		"│ ╭─────╯",
-		"│ ╰─────╮",
-		"│ ╭───╮ │",
-		"│ ╰───╯ │",
-		"╰───────╯",
-	},
-	'7': {
-		"╭─────╮",
-		"╰───╮ │",
-		"    │ │",
-		"    │ │",
-		"    │ │",
-		"    ╰─╯",
-	},
-	'8': {
-		"╭───────╮",
-		"│ ╭───╮ │",
-		"│ ╰───╯ │",
-		"│ ╭───╮ │",
-		"│ ╰───╯ │",
-		"╰───────╯",
-	},
-	'9': {
-		"╭───────╮",
-		"│ ╭───╮ │",
-		"│ ╰───╯ │",
-		"╰─────╮ │",
-		"╭─────╯ │",
-		"╰───────╯",
-	},
-	':': {
-		"   ",
-		"╭─╮",
-		"╰─╯",
-		"╭─╮",
-		"╰─╯",
-		"   ",
-	},
-}
+// Format selects how the current count is rendered.
+type Format int
+
+const (
+	// FormatNumber renders the count as a plain integer.
+	FormatNumber Format = iota
+	// FormatClock renders the count, taken as seconds, as HH:MM:SS.
+	FormatClock
+)
+
+// SignalAction selects what a shutdown signal such as SIGTERM does.
+type SignalAction int
+
+const (
+	// SignalShow displays "(killed)" for a moment and then exits.
+	SignalShow SignalAction = iota
+	// SignalExit exits immediately.
+	SignalExit
+	// SignalIgnore carries on counting.
+	SignalIgnore
+)
+
+// killedLinger is how long the "(killed)" frame is shown with SignalShow.
+const killedLinger = time.Second
 
 // Config holds the countdown configuration.
 type Config struct {
@@ -113,7 +49,7 @@ type Config struct {
 	Title             string
 	Start             int
 	End               int
-	TimeInterval      int
+	TimeInterval      time.Duration
 	Decrement         int
 	FinalPhase        int
 	SpinnerForeground string
@@ -123,6 +59,98 @@ type Config struct {
 	PaddingVertical   int
 	PaddingHorizontal int
 	Big               bool
+	Format            Format
+	// Font draws the count with Big; the default font is used when it has
+	// no name.
+	Font Font
+	// BigTitle draws the title in big letters too with Big, beside the count
+	// when the terminal is wide enough and above it otherwise. The progress
+	// bar goes beneath them.
+	BigTitle bool
+	// DigitStyle colors the big digits, with DigitColors: the two ends of a
+	// gradient, or the color of the digits which changed. The ends of the
+	// progress bar's default gradient are used when empty.
+	DigitStyle  DigitStyle
+	DigitColors []string
+	// ColorFrom and ColorTo, when both are set, blend the count's color from
+	// one to the other as it goes from Start to End, in place of the final
+	// phase. Given stages still take over from the blend.
+	ColorFrom string
+	ColorTo   string
+	// Precision is the number of decimal places displayed. Start, End,
+	// Decrement and FinalPhase are all counted in units of 10^-Precision,
+	// so a Start of 47 with a Precision of 1 displays as 4.7.
+	Precision int
+	// PrecisionFinal only displays the decimal places in the final phase.
+	PrecisionFinal bool
+	// Adjust is the amount added or subtracted by the + and - keys.
+	Adjust int
+	// Deadline, when set, makes the count the time remaining until that
+	// instant, measured against the real clock. Start should hold the time
+	// remaining when the countdown begins.
+	Deadline time.Time
+	// Clock supplies the time; the system clock is used when nil.
+	Clock Clock
+	// Stop, when closed, ends the countdown early as if a StopMsg were sent.
+	Stop <-chan struct{}
+	// OnSignal selects what SIGTERM, SIGINT, SIGHUP and SIGQUIT do.
+	OnSignal SignalAction
+	// Plain prints one unstyled line each time the count changes instead of
+	// drawing on the terminal, for logs and pipes.
+	Plain bool
+	// Overtime carries on counting up past the end, with a "+" sign, until
+	// the user quits.
+	Overtime bool
+	// OvertimeMax, when positive, ends the countdown after this much
+	// overtime, in the same units as Start.
+	OvertimeMax int
+	// Stages change the style, spinner and title as the count reaches each
+	// threshold. Without any, the final phase blinks in the final style.
+	// With them FinalPhase still marks where EventFinalPhaseEntered is sent,
+	// PrecisionFinal takes effect and the skip key jumps to.
+	Stages []Stage
+	// Theme supplies the colors, which the foreground and background colors
+	// above override. The default theme is used when it has no name.
+	Theme Theme
+	// Output receives the display; standard output is used when nil.
+	Output io.Writer
+	// Input supplies the keys pressed; the terminal is used when nil.
+	Input io.Reader
+	// Printers print above the countdown while it runs, so that output such
+	// as a wrapped command's isn't drawn over.
+	Printers []*Printer
+	// OnEvent, when set, is called with each event in the countdown's life,
+	// such as each tick, pausing and finishing.
+	OnEvent func(Event)
+	// Endless counts up from Start like a stopwatch until the user quits.
+	// End, FinalPhase and Overtime are ignored.
+	Endless bool
+	// Sequence, when set, counts down each segment in turn as one
+	// countdown, ringing the bell between them. Start, End, FinalPhase and
+	// Stages are taken from each segment.
+	Sequence []Segment
+	// Agenda titles each segment of a sequence "Now: X — Next: Y", for
+	// talks and meetings, instead of numbering them.
+	Agenda bool
+	// AgendaTitle, when set with Agenda, leads the title of each segment, as
+	// in "Track A — Now: X — Next: Y".
+	AgendaTitle string
+	// Display selects what shows the count; the number alone when zero.
+	Display Display
+	// ProgressBeside draws the progress bar beside the title instead of
+	// beneath the count.
+	ProgressBeside bool
+	// ProgressColors fill the progress bar: one color for a solid fill, or
+	// two for a gradient between them. The default gradient is used when
+	// empty.
+	ProgressColors []string
+	// Align positions the display across the terminal, and VAlign up and
+	// down it in Fullscreen. The zero values are left and top.
+	Align  lipgloss.Position
+	VAlign lipgloss.Position
+	// Fullscreen draws on the terminal's alternate screen, clearing it for
+	// the countdown and restoring it afterwards.
+	Fullscreen bool
 }
 
 // Model represents the Bubbletea model for the countdown.
@@ -131,109 +159,264 @@ type Model struct {
 	spinner        spinner.Model
 	current        int
 	done           bool
+	completed      bool
+	aborted        bool
+	stopped        bool
 	killed         bool
+	signal         os.Signal
 	spinnerStyle   lipgloss.Style
 	titleStyle     lipgloss.Style
 	countStyle     lipgloss.Style
 	containerStyle lipgloss.Style
+	stages         []stageView
+	stage          int
+	// overtime is set while the count is past the end with Config.Overtime.
+	overtime bool
+	engine   engine
+	clock    Clock
+	tickTag  int
+	keys     keyMap
+	help     help.Model
+	showHelp bool
+	// inFinalPhase records whether the final phase had been entered when
+	// the count was last advanced.
+	inFinalPhase bool
+	// laps are those recorded with the lap key, with the count at each.
+	laps      []Lap
+	lapCounts []int
+	// base is the configuration given for a sequence, and segment the index
+	// of the segment being counted down.
+	base    Config
+	segment int
+	// bar draws the progress bar, at most width cells wide, where width and
+	// height are the terminal's once known.
+	bar    progress.Model
+	width  int
+	height int
+	// changedFrom is the count as shown before it last changed.
+	changedFrom string
+	// rung counts the bells rung, so that Update can ring the terminal's.
+	rung int
 }
 
-// tickMsg is sent when the countdown should decrement.
-type tickMsg struct{}
+// tickMsg is sent when the countdown should decrement. Ticks carrying an old
+// tag are dropped, so that at most one chain of ticks is ever running.
+type tickMsg struct {
+	tag int
+}
 
 // shutdownMsg is sent when the OS is shutting down.
-type shutdownMsg struct{}
+type shutdownMsg struct {
+	signal os.Signal
+}
+
+// pauseMsg is sent to pause or resume the countdown, on SIGUSR1.
+type pauseMsg struct{}
+
+// restartMsg is sent to restart the countdown, on SIGUSR2.
+type restartMsg struct{}
+
+// StopMsg ends the countdown early because whatever it was timing has
+// finished, such as a command run alongside it.
+type StopMsg struct{}
 
 // NewModel creates a new countdown model.
 func NewModel(cfg Config) Model {
-	s := spinner.New()
-	s.Spinner = GetSpinner(cfg.SpinnerType)
+	clock := cfg.Clock
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	if len(cfg.Sequence) > 0 {
+		m := NewModel(cfg.segmentConfig(0, clock.Now()))
+		m.base = cfg
+		m.keys.Next.SetEnabled(true)
+		m.keys.Previous.SetEnabled(true)
+		return m
+	}
+	if cfg.Endless {
+		cfg.End, cfg.Overtime = cfg.Start, false
+	}
+	if cfg.Display == 0 {
+		cfg.Display = DisplayNumber
+	}
+	if cfg.Font.Name == "" {
+		cfg.Font = fonts[DefaultFont]
+	}
 
-	// Build spinner style
-	spinnerStyle := lipgloss.NewStyle()
-	if cfg.SpinnerForeground != "" {
-		spinnerStyle = spinnerStyle.Foreground(parseColor(cfg.SpinnerForeground))
+	theme := cfg.Theme
+	if theme.Name == "" {
+		theme = themes["default"]
 	}
+	background := theme.color(func(p Palette) string { return p.Background }, "")
+
+	// Build spinner style
+	spinnerStyle := lipgloss.NewStyle().
+		Foreground(theme.color(func(p Palette) string { return p.Spinner }, cfg.SpinnerForeground)).
+		Background(background)
 	if cfg.SpinnerBackground != "" {
 		spinnerStyle = spinnerStyle.Background(parseColor(cfg.SpinnerBackground))
 	}
-	s.Style = spinnerStyle
 
 	// Build title style
-	titleStyle := lipgloss.NewStyle()
-	if cfg.TitleForeground != "" {
-		titleStyle = titleStyle.Foreground(parseColor(cfg.TitleForeground))
-	}
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.color(func(p Palette) string { return p.Title }, cfg.TitleForeground)).
+		Background(background)
 	if cfg.TitleBackground != "" {
 		titleStyle = titleStyle.Background(parseColor(cfg.TitleBackground))
 	}
 
-	// Count style (same as title by default)
-	countStyle := titleStyle
+	// Count style (same as title, in the theme's count color)
+	countStyle := titleStyle.
+		Foreground(theme.color(func(p Palette) string { return p.Count }, cfg.TitleForeground))
+
+	// Final phase style, swapping any foreground color given by flag
+	finalStyle := theme.finalStyle(cmp.Or(cfg.TitleForeground, cfg.SpinnerForeground))
 
 	// Container style with padding
 	containerStyle := lipgloss.NewStyle().
 		PaddingTop(cfg.PaddingVertical).
 		PaddingBottom(cfg.PaddingVertical).
 		PaddingLeft(cfg.PaddingHorizontal).
-		PaddingRight(cfg.PaddingHorizontal)
+		PaddingRight(cfg.PaddingHorizontal).
+		Background(background)
+
+	e := newEngine(cfg.Start, cfg.End, cfg.Decrement, cfg.TimeInterval, clock.Now())
+	if !cfg.Deadline.IsZero() {
+		if cfg.Decrement < 1 || cfg.TimeInterval <= 0 {
+			// Count down each unit without a step and interval
+			e.step, e.interval = 1, time.Second/time.Duration(Scale(cfg.Precision))
+		}
+		e.anchorOn(cfg.Deadline)
+	}
+	e.overtime = cfg.Overtime
+	e.endless = cfg.Endless
 
-	return Model{
+	m := Model{
 		config:         cfg,
-		spinner:        s,
 		current:        cfg.Start,
 		killed:         false,
 		spinnerStyle:   spinnerStyle,
 		titleStyle:     titleStyle,
 		countStyle:     countStyle,
 		containerStyle: containerStyle,
+		stages:         newStages(cfg, countStyle, finalStyle, theme.overtimeStyle(countStyle)),
+		engine:         e,
+		clock:          clock,
+		keys:           defaultKeyMap(cfg.Endless),
+		bar:            newProgressBar(cfg.ProgressColors),
+		help:           help.New(),
 	}
+	m.inFinalPhase = m.isInFinalPhase()
+	m.stage = m.currentStage()
+	m.spinner = m.newSpinner()
+	return m
+}
+
+// newSpinner returns a spinner of the type for the current stage.
+func (m Model) newSpinner() spinner.Model {
+	s := spinner.New()
+	s.Spinner = GetSpinner(m.spinnerType())
+	s.Style = m.spinnerStyle
+	return s
 }
 
 // Init initializes the model.
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, tick(m.config.TimeInterval))
+	m.start()
+	return tea.Batch(m.spinner.Tick, m.tick())
 }
 
-// tick returns a command that sends a tickMsg after the specified interval.
-func tick(seconds int) tea.Cmd {
-	return tea.Tick(time.Duration(seconds)*time.Second, func(time.Time) tea.Msg {
-		return tickMsg{}
-	})
+// start sends the events for the beginning of the countdown.
+func (m Model) start() {
+	m.emit(EventStart)
+	if m.inFinalPhase {
+		m.emit(EventFinalPhaseEntered)
+	}
+}
+
+// tick returns a command that sends a tickMsg when the next step falls due.
+func (m Model) tick() tea.Cmd {
+	clock, tag := m.clock, m.tickTag
+	wait := m.engine.untilNext(clock.Now())
+	return func() tea.Msg {
+		<-clock.After(wait)
+		return tickMsg{tag: tag}
+	}
 }
 
 // Update handles messages and updates the model.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	spinnerID := m.spinner.ID()
+	updated, cmd := m.update(msg)
+	next := updated.(Model)
+	if next.spinner.ID() != spinnerID && !next.done {
+		// A stage changed the spinner, so start the new one turning
+		cmd = tea.Batch(cmd, next.spinner.Tick)
+	}
+	if next.rung != m.rung {
+		cmd = tea.Batch(cmd, ringBell)
+	}
+	return updated, cmd
+}
+
+// update handles a message for Update.
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c", "esc":
-			m.done = true
+		return m.handleKey(msg)
+
+	case tickMsg:
+		if msg.tag != m.tickTag || m.engine.paused() {
+			return m, nil
+		}
+		if m.advance() {
 			return m, tea.Quit
 		}
+		m.emit(EventTick)
+		return m, m.tick()
 
-	case tickMsg:
-		// Determine direction
-		if m.config.Start > m.config.End {
-			m.current -= m.config.Decrement
-			if m.current <= m.config.End {
-				m.current = m.config.End
-				m.done = true
-				return m, tea.Quit
-			}
-		} else {
-			m.current += m.config.Decrement
-			if m.current >= m.config.End {
-				m.current = m.config.End
-				m.done = true
-				return m, tea.Quit
-			}
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case tea.ResumeMsg:
+		// Catch up on the time spent suspended. The pending tick carries on
+		// afterwards, so don't schedule another.
+		if m.advance() {
+			return m, tea.Quit
 		}
-		return m, tick(m.config.TimeInterval)
+		return m, nil
+
+	case StopMsg:
+		m.done = true
+		m.stopped = true
+		m.emit(EventStopped)
+		return m, tea.Quit
 
 	case shutdownMsg:
+		switch m.config.OnSignal {
+		case SignalIgnore:
+			return m, nil
+		case SignalExit:
+			m.killed = true
+			m.signal = msg.signal
+			m.emit(EventKilled)
+			return m, tea.Quit
+		}
 		m.killed = true
-		return m, nil
+		m.signal = msg.signal
+		m.emit(EventKilled)
+		clock := m.clock
+		return m, func() tea.Msg {
+			<-clock.After(killedLinger)
+			return tea.QuitMsg{}
+		}
+
+	case pauseMsg:
+		return m.togglePause()
+
+	case restartMsg:
+		return m.restart()
 
 	case spinner.TickMsg:
 		var cmd tea.Cmd
@@ -244,14 +427,174 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleKey handles a key press.
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	now := m.clock.Now()
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		m.done = true
+		if m.completed {
+			// Quitting in overtime is how it ends, having reached the end
+			m.emit(EventOvertimeEnded)
+			return m, tea.Quit
+		}
+		m.aborted = true
+		m.emit(EventAborted)
+		return m, tea.Quit
+
+	case msg.String() == "ctrl+z":
+		return m, tea.Suspend
+
+	case key.Matches(msg, m.keys.Help):
+		m.showHelp = !m.showHelp
+		return m, nil
+
+	case key.Matches(msg, m.keys.Pause):
+		return m.togglePause()
+
+	case key.Matches(msg, m.keys.Add):
+		m.engine.adjust(m.config.Adjust)
+
+	case key.Matches(msg, m.keys.Subtract):
+		m.engine.adjust(-m.config.Adjust)
+
+	case key.Matches(msg, m.keys.Restart):
+		return m.restart()
+
+	case key.Matches(msg, m.keys.Next):
+		return m.moveSegment(1)
+
+	case key.Matches(msg, m.keys.Previous):
+		return m.moveSegment(-1)
+
+	case key.Matches(msg, m.keys.Lap):
+		m.lap()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Skip):
+		if !m.isInFinalPhase() {
+			m.engine.jumpTo(m.config.FinalPhase, now)
+		}
+
+	default:
+		return m, nil
+	}
+
+	if m.advance() {
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// togglePause pauses a running countdown or resumes a paused one.
+func (m Model) togglePause() (tea.Model, tea.Cmd) {
+	now := m.clock.Now()
+	if m.engine.paused() {
+		m.engine.resume(now)
+		m.emit(EventResumed)
+		return m, m.restartTicks()
+	}
+	m.engine.pause(now)
+	if m.advance() {
+		return m, tea.Quit
+	}
+	m.emit(EventPaused)
+	return m, nil
+}
+
+// restart begins the countdown again from the start.
+func (m Model) restart() (tea.Model, tea.Cmd) {
+	m.engine.restart(m.clock.Now())
+	if !m.config.Deadline.IsZero() {
+		// Count down to the deadline again, rather than for as long from now
+		m.engine.anchorOn(m.config.Deadline)
+	}
+	m.laps, m.lapCounts = nil, nil
+	if m.inSequence() {
+		m = m.toSegment(0, m.clock.Now())
+	}
+	if m.advance() {
+		return m, tea.Quit
+	}
+	return m, m.restartTicks()
+}
+
+// restartTicks abandons any tick in flight and schedules the next one afresh,
+// unless paused.
+func (m *Model) restartTicks() tea.Cmd {
+	m.tickTag++
+	if m.engine.paused() {
+		return nil
+	}
+	return m.tick()
+}
+
+// advance brings the count up to date with the clock and reports whether the
+// countdown has finished, sending events for the phases it enters.
+func (m *Model) advance() bool {
+	wasCompleted := m.completed
+	shown := m.formatCount()
+	m.current, m.completed = m.engine.value(m.clock.Now())
+	if m.completed && m.segment+1 < len(m.base.Sequence) {
+		// Carry on with the next segment from the moment this one ended
+		*m = m.toSegment(m.segment+1, m.engine.endsAt())
+		m.bell()
+		m.emit(EventSegment)
+		if m.inFinalPhase {
+			m.emit(EventFinalPhaseEntered)
+		}
+		return m.advance()
+	}
+	m.overtime = m.config.Overtime && m.completed && m.current != m.config.End
+	m.done = m.completed && !m.config.Overtime
+	if limit := m.config.OvertimeMax; m.overtime && limit > 0 && abs(m.current-m.config.End) >= limit {
+		if m.config.Start > m.config.End {
+			limit = -limit
+		}
+		m.current = m.config.End + limit
+		m.done = true
+	}
+	if m.formatCount() != shown {
+		m.changedFrom = shown
+	}
+
+	inFinalPhase := m.isInFinalPhase()
+	if inFinalPhase && !m.inFinalPhase {
+		m.emit(EventFinalPhaseEntered)
+	}
+	m.inFinalPhase = inFinalPhase
+	if stage := m.currentStage(); stage != m.stage {
+		spinnerType := m.spinnerType()
+		m.stage = stage
+		if m.spinnerType() != spinnerType {
+			m.spinner = m.newSpinner()
+		}
+	}
+	if m.completed && !wasCompleted {
+		m.emit(EventDone)
+	}
+	if m.overtime && m.done {
+		m.emit(EventOvertimeEnded)
+	}
+	return m.done
+}
+
 // View renders the model.
 func (m Model) View() string {
 	if m.done {
 		return ""
 	}
 
-	// Check if we're in final phase
-	inFinalPhase := m.isInFinalPhase()
+	// Check for a stage style, such as the final phase
+	countStyle := m.countStyle
+	if color, ok := m.countColor(); ok {
+		countStyle = countStyle.Foreground(color)
+	}
+	stageStyle, inStage := m.stageStyle()
+	if inStage {
+		countStyle = stageStyle
+	}
 
 	// Build the spinner view
 	spinnerView := m.spinner.View()
@@ -259,98 +602,211 @@ func (m Model) View() string {
 	// Build the title and count with potential style swap in final phase.
 	//
 	// Add space to title for unbroken display when inverted.
-	titleStr := fmt.Sprintf("%s ", m.config.Title)
-	if m.killed {
-		titleStr += "(killed) "
+	titleView := m.titleStyle.Render(m.titleText() + " ")
+	head := fmt.Sprintf("%s %s", spinnerView, titleView)
+
+	// Big digits which don't fit the terminal fall back to the plain number
+	big := m.config.Big && m.bigFits()
+
+	// The count is shown as a number, a percentage or both
+	var counts []string
+	if m.shows(DisplayNumber) {
+		if big {
+			// Render big ASCII art numbers
+			counts = append(counts, m.renderBigCount(countStyle, inStage))
+		} else {
+			counts = append(counts, countStyle.Render(m.formatCount()))
+		}
 	}
+	if m.shows(DisplayPercent) {
+		if len(counts) > 0 {
+			counts = append(counts, " ")
+		}
+		counts = append(counts, countStyle.Render(formatPercent(m.percent())))
+	}
+	countView := lipgloss.JoinHorizontal(lipgloss.Bottom, counts...)
 
-	var titleView string
-	var countView string
-
-	if m.config.Big {
-		// Render big ASCII art numbers
-		bigNumStr := renderBigNumber(m.current)
-		titleView = m.titleStyle.Render(titleStr)
-		
-		if inFinalPhase && m.current%2 == 1 {
-			// Final phase: foreground becomes background, text is high-contrast
-			finalStyle := lipgloss.NewStyle()
-
-			// Determine the foreground color to use as background
-			fgColor := m.config.SpinnerForeground // Default foreground
-			if m.config.TitleForeground != "" {
-				fgColor = m.config.TitleForeground
+	var content string
+	bigTitle, beside := false, false
+	if big && m.config.BigTitle {
+		bigTitle, beside = m.bigTitleLayout(countView)
+	}
+	switch {
+	case bigTitle:
+		// The title in big letters too, beside the count when there's room
+		title := lipgloss.JoinHorizontal(lipgloss.Top, spinnerView+" ", m.titleStyle.Render(titleFont.Render(m.titleText())))
+		switch {
+		case countView == "":
+			content = title
+		case beside:
+			content = lipgloss.JoinHorizontal(lipgloss.Top, title, titleGap, countView)
+		default:
+			content = lipgloss.JoinVertical(lipgloss.Left, title, countView)
+		}
+	case big:
+		// For big numbers, render title and number on separate lines, with
+		// the bar beside the title
+		if m.shows(DisplayBar) && m.config.ProgressBeside {
+			head += m.renderBar(m.percent(), m.barWidth(lipgloss.Width(head)))
+		}
+		content = head
+		if countView != "" {
+			content += "\n" + countView
+		}
+	default:
+		content = head + countView
+		if m.shows(DisplayBar) && m.config.ProgressBeside {
+			if countView != "" {
+				content += " "
 			}
+			content += m.renderBar(m.percent(), m.barWidth(lipgloss.Width(content)))
+		}
+	}
+	if m.shows(DisplayBar) && (!m.config.ProgressBeside || bigTitle) {
+		content += "\n" + m.renderBar(m.percent(), m.barWidth(0))
+	}
 
-			// Set the original foreground as the new background
-			if fgColor != "" {
-				finalStyle = finalStyle.Background(parseColor(fgColor))
-			} else {
-				// Use default spinner color (212) as background
-				fgColor = "212"
-				finalStyle = finalStyle.Background(parseColor(fgColor))
-			}
+	return m.place(m.containerStyle.Render(m.withHelp(m.withLaps(m.withSequence(content)))))
+}
 
-			// Calculate high-contrast foreground for readability
-			finalStyle = finalStyle.Foreground(highContrastColor(fgColor))
-			finalStyle = finalStyle.Bold(true)
+// PlainView renders the model as a single line of text without any styling,
+// such as "Liftoff in 5", for output which is not a terminal. A progress bar
+// is shown as a percentage.
+func (m Model) PlainView() string {
+	var counts []string
+	if m.shows(DisplayNumber) {
+		counts = append(counts, m.formatCount())
+	}
+	if m.shows(DisplayPercent) || !m.shows(DisplayNumber) {
+		counts = append(counts, formatPercent(m.percent()))
+	}
+	return m.titleText() + " " + strings.Join(counts, " ")
+}
 
-			countView = finalStyle.Render(bigNumStr)
-		} else {
-			countView = m.countStyle.Render(bigNumStr)
-		}
+// titleText returns the title followed by any status indicators.
+func (m Model) titleText() string {
+	title := m.config.Title
+	if m.stage >= 0 && m.stages[m.stage].Title != "" {
+		title = m.stages[m.stage].Title
+	}
+	switch {
+	case m.inSequence() && m.base.Agenda:
+		title = m.agendaTitle(title)
+	case m.inSequence():
+		title += fmt.Sprintf(" %d/%d", m.segment+1, len(m.base.Sequence))
+	}
+	if m.killed {
+		title += " (killed)"
+	}
+	if m.engine.paused() {
+		title += " (paused)"
+	}
+	return title
+}
 
-		// For big numbers, render title and number on separate lines
-		content := fmt.Sprintf("%s %s\n%s", spinnerView, titleView, countView)
-		return m.containerStyle.Render(content)
+// withHelp adds the key binding help beneath the content when it is toggled on.
+func (m Model) withHelp(content string) string {
+	if !m.showHelp {
+		return content
 	}
+	h := m.help
+	h.ShowAll = true
+	return content + "\n\n" + h.View(m.keys)
+}
 
-	// Regular number rendering
-	countStr := strconv.Itoa(m.current)
-	titleView = m.titleStyle.Render(titleStr)
-	
-	if inFinalPhase && m.current%2 == 1 {
-		// Final phase: foreground becomes background, text is high-contrast
-		finalStyle := lipgloss.NewStyle()
+// withLaps adds the lap table beneath the content once there are any laps.
+func (m Model) withLaps(content string) string {
+	if table := m.lapTable(); table != "" {
+		return content + "\n\n" + table
+	}
+	return content
+}
 
-		// Determine the foreground color to use as background
-		fgColor := m.config.SpinnerForeground // Default foreground
-		if m.config.TitleForeground != "" {
-			fgColor = m.config.TitleForeground
-		}
+// formatCount returns the current count as text in the configured format.
+func (m Model) formatCount() string {
+	if m.overtime {
+		// Overtime counts up from the end
+		return "+" + m.formatValue(abs(m.current-m.config.End))
+	}
+	return m.formatValue(m.current)
+}
+
+// formatValue returns a count as text in the configured format.
+func (m Model) formatValue(value int) string {
+	scale := Scale(m.config.Precision)
+	whole, frac := value/scale, value%scale
+	sign := ""
+	if value < 0 {
+		sign, whole, frac = "-", -whole, -frac
+	}
 
-		// Set the original foreground as the new background
-		if fgColor != "" {
-			finalStyle = finalStyle.Background(parseColor(fgColor))
+	decimals := ""
+	if m.config.Precision > 0 {
+		if m.config.PrecisionFinal && !m.isInFinalPhase() {
+			// Round toward the start, so that a countdown shows 5 until it
+			// reaches 4.0 just as it would without decimals
+			if frac != 0 && (m.config.Start > m.config.End) == (value > 0) {
+				whole++
+			}
 		} else {
-			// Use default spinner color (212) as background
-			fgColor = "212"
-			finalStyle = finalStyle.Background(parseColor(fgColor))
+			decimals = fmt.Sprintf(".%0*d", m.config.Precision, frac)
 		}
+	}
 
-		// Calculate high-contrast foreground for readability
-		finalStyle = finalStyle.Foreground(highContrastColor(fgColor))
-		finalStyle = finalStyle.Bold(true)
-
-		countView = finalStyle.Render(countStr)
-	} else {
-		countView = m.countStyle.Render(countStr)
+	if m.config.Format == FormatClock {
+		return sign + formatClock(whole) + decimals
 	}
+	return sign + strconv.Itoa(whole) + decimals
+}
+
+// formatClock formats a number of seconds as HH:MM:SS.
+func formatClock(seconds int) string {
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
 
-	// Combine all parts
-	content := fmt.Sprintf("%s %s%s", spinnerView, titleView, countView)
+// blinkOn reports whether the blinking final phase style is shown, which
+// alternates with each whole number of the count.
+func (m Model) blinkOn() bool {
+	return abs(m.current/Scale(m.config.Precision))%2 == 1
+}
 
-	return m.containerStyle.Render(content)
+// Scale returns the number of units in one at a precision, 10^precision,
+// which is what Start, End and the other counts of Config are multiplied by.
+func Scale(precision int) int {
+	p := 1
+	for range precision {
+		p *= 10
+	}
+	return p
 }
 
 // isInFinalPhase checks if the current count is in the final phase.
 func (m Model) isInFinalPhase() bool {
-	if m.config.Start > m.config.End {
-		// Counting down
-		return m.current <= m.config.FinalPhase
+	if m.config.Endless {
+		return false
 	}
-	// Counting up
-	return m.current >= m.config.FinalPhase
+	return m.reached(m.config.FinalPhase)
+}
+
+// colorNames maps the names of the basic ANSI colors to their numbers.
+var colorNames = map[string]string{
+	"black":          "0",
+	"red":            "1",
+	"green":          "2",
+	"yellow":         "3",
+	"blue":           "4",
+	"magenta":        "5",
+	"cyan":           "6",
+	"white":          "7",
+	"gray":           "8",
+	"grey":           "8",
+	"bright-red":     "9",
+	"bright-green":   "10",
+	"bright-yellow":  "11",
+	"bright-blue":    "12",
+	"bright-magenta": "13",
+	"bright-cyan":    "14",
+	"bright-white":   "15",
 }
 
 // parseColor parses a color string and returns a lipgloss.TerminalColor.
@@ -359,6 +815,9 @@ func parseColor(s string) lipgloss.TerminalColor {
 	if s == "" {
 		return lipgloss.NoColor{}
 	}
+	if n, ok := colorNames[strings.ToLower(s)]; ok {
+		s = n
+	}
 
 	// Check if it's a number (ANSI color)
 	if _, err := strconv.Atoi(s); err == nil {
@@ -372,9 +831,15 @@ func parseColor(s string) lipgloss.TerminalColor {
 // highContrastColor returns a high-contrast foreground color (black or white)
 // for the given background color string.
 func highContrastColor(bgColor string) lipgloss.TerminalColor {
+	return lipgloss.Color(highContrast(bgColor))
+}
+
+// highContrast returns the ANSI number of black or white, whichever stands out
+// against the given background color string.
+func highContrast(bgColor string) string {
 	bgColor = strings.TrimSpace(bgColor)
 	if bgColor == "" {
-		return lipgloss.Color("15") // White for default/empty background
+		return "15" // White for default/empty background
 	}
 
 	r, g, b := colorToRGB(bgColor)
@@ -382,9 +847,9 @@ func highContrastColor(bgColor string) lipgloss.TerminalColor {
 
 	// Hard code a threshold which works in practice
 	if luminance > 0.4 {
-		return lipgloss.Color("0") // Black
+		return "0" // Black
 	}
-	return lipgloss.Color("15") // White
+	return "15" // White
 }
 
 // colorToRGB converts a color string to RGB values (0-255).
@@ -396,7 +861,10 @@ func colorToRGB(s string) (r, g, b uint8) {
 		return hexToRGB(s)
 	}
 
-	// Handle ANSI 256 colors
+	// Handle ANSI 256 colors, by number or name
+	if n, ok := colorNames[strings.ToLower(s)]; ok {
+		s = n
+	}
 	if num, err := strconv.Atoi(s); err == nil {
 		return ansi256ToRGB(num)
 	}
@@ -405,6 +873,24 @@ func colorToRGB(s string) (r, g, b uint8) {
 	return 255, 255, 255
 }
 
+// ValidColor reports whether s is a color which can be converted to RGB: a hex
+// code, an ANSI number from 0 to 255 or a color name.
+func ValidColor(s string) bool {
+	s = strings.TrimSpace(s)
+	if hex, ok := strings.CutPrefix(s, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	if n, ok := colorNames[strings.ToLower(s)]; ok {
+		s = n
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
 // hexToRGB converts a hex color string to RGB.
 func hexToRGB(hex string) (r, g, b uint8) {
 	hex = strings.TrimPrefix(hex, "#")
@@ -493,46 +979,3 @@ func calcLuminance(r, g, b uint8) float64 {
 	// Calculate luminance (ITU-R BT.709)
 	return 0.2126*rLin + 0.7152*gLin + 0.0722*bLin
 }
-
-// renderBigNumber renders a number as large ASCII art digits.
-func renderBigNumber(num int) string {
-	numStr := strconv.Itoa(num)
-	lines := make([][]string, 6)
-	for i := range lines {
-		lines[i] = make([]string, 0)
-	}
-
-	for _, char := range numStr {
-		if digit, ok := bigDigits[char]; ok {
-			for i, line := range digit {
-				lines[i] = append(lines[i], line)
-			}
-		}
-	}
-
-	var result strings.Builder
-	for _, line := range lines {
-		if len(line) > 0 {
-			result.WriteString(strings.Join(line, ""))
-			result.WriteString("\n")
-		}
-	}
-	return strings.TrimRight(result.String(), "\n")
-}
-
-// Run starts the countdown application.
-func Run(cfg Config) error {
-	p := tea.NewProgram(NewModel(cfg))
-
-	// Set up signal handling for OS shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
-
-	go func() {
-		<-sigChan
-		p.Send(shutdownMsg{})
-	}()
-
-	_, err := p.Run()
-	return err
-}
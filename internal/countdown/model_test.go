@@ -2,8 +2,12 @@ package countdown
 
 import (
 	"fmt"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -37,7 +41,7 @@ func TestNewModel(t *testing.T) {
 		Title:             "Test",
 		Start:             10,
 		End:               0,
-		TimeInterval:      1,
+		TimeInterval:      time.Second,
 		Decrement:         1,
 		FinalPhase:        2,
 		SpinnerForeground: "212",
@@ -93,6 +97,8 @@ func TestParseColor(t *testing.T) {
 		{"empty string", "", lipgloss.NoColor{}},
 		{"ansi number", "212", lipgloss.Color("212")},
 		{"hex color", "#ff0000", lipgloss.Color("#ff0000")},
+		{"named color", "Yellow", lipgloss.Color("3")},
+		{"bright named color", "bright-red", lipgloss.Color("9")},
 	}
 
 	for _, tt := range tests {
@@ -114,7 +120,7 @@ func TestModelView(t *testing.T) {
 		Title:        "Test",
 		Start:        10,
 		End:          0,
-		TimeInterval: 1,
+		TimeInterval: time.Second,
 		Decrement:    1,
 		FinalPhase:   2,
 	}
@@ -135,7 +141,7 @@ func TestModelViewWithKilled(t *testing.T) {
 		Title:        "Test",
 		Start:        10,
 		End:          0,
-		TimeInterval: 1,
+		TimeInterval: time.Second,
 		Decrement:    1,
 		FinalPhase:   2,
 	}
@@ -230,6 +236,32 @@ func TestHexToRGB(t *testing.T) {
 	}
 }
 
+func TestValidColor(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"#ff0000", true},
+		{"#F00", true},
+		{" 196 ", true},
+		{"0", true},
+		{"red", true},
+		{"Bright-White", true},
+		{"#ff00", false},
+		{"#gg0000", false},
+		{"256", false},
+		{"-1", false},
+		{"rouge", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidColor(tt.input))
+		})
+	}
+}
+
 func TestRenderBigNumber(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -241,7 +273,7 @@ func TestRenderBigNumber(t *testing.T) {
 		{"two", 2, []string{"╭───────╮", "╰─────╮ │", "╭─────╯ │", "╰───────╯"}},
 		{"three", 3, []string{"╭───────╮", "╰─────╮ │", "╭─────╯ │", "╰───────╯"}},
 		{"multi-digit", 123, []string{"╭───╮", "╰─╮ │", "  │ │"}}, // Should contain parts of 1, 2, 3
-		{"negative", -5, []string{"╭───────╮", "│ ╭─────╯", "╰───────╯"}}, // Should render the 5 part
+		{"negative", -5, []string{"╭─────╮│ ╰─────╮", "╰─────╯╰─────╮ │"}}, // Should render a minus sign before the 5
 		{"large number", 9876543210, []string{"╭───────╮"}}, // Should render all digits
 	}
 
@@ -258,13 +290,514 @@ func TestRenderBigNumber(t *testing.T) {
 	}
 }
 
+func TestRenderBigTextSigns(t *testing.T) {
+	plus := strings.Split(renderBigText("+1"), "\n")
+	require.Len(t, plus, 6)
+	assert.Equal(t, "╭──╯ ╰──╮  │ │", plus[2])
+	assert.Equal(t, "╰──╮ ╭──╯  │ │", plus[3])
+	for _, line := range plus {
+		assert.Equal(t, 9+5, lipgloss.Width(line), "Every line of a glyph should be the same width")
+	}
+}
+
+func TestModelOvertime(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	cfg := Config{
+		Title:        "Talk",
+		Start:        3,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		Clock:        clock,
+		Overtime:     true,
+	}
+	var events []Event
+	cfg.OnEvent = func(e Event) { events = append(events, e) }
+	m := NewModel(cfg)
+
+	tick := func() tea.Cmd {
+		clock.Advance(time.Second)
+		updated, cmd := m.Update(tickMsg{tag: m.tickTag})
+		m = updated.(Model)
+		return cmd
+	}
+
+	for range 3 {
+		tick()
+	}
+	assert.Equal(t, "Talk 0", m.PlainView())
+	assert.False(t, m.done, "Overtime should carry on past the end")
+	assert.Contains(t, eventTypes(events), EventDone, "Reaching the end should still send done")
+
+	for range 5 {
+		assert.NotNil(t, tick())
+	}
+	assert.Equal(t, "Talk +5", m.PlainView())
+	assert.Equal(t, 5.0, events[len(events)-1].Overtime)
+	assert.Equal(t, 0.0, events[len(events)-1].Remaining)
+	assert.True(t, m.stages[m.stage].Overtime, "The overtime stage should apply")
+	assert.Contains(t, m.View(), "+5")
+
+	m, _ = pressKey(m, "q")
+	assert.Equal(t, Result{Outcome: Completed, Count: "+5"}, m.result(), "Quitting in overtime should count as completed")
+	assert.False(t, m.aborted)
+	assert.Equal(t, EventOvertimeEnded, events[len(events)-1].Type, "Quitting in overtime should end it rather than abort")
+	assert.NotContains(t, eventTypes(events), EventAborted)
+
+	cfg.OvertimeMax = 20
+	cfg.Precision = 1
+	cfg.Start, cfg.Decrement = 30, 10
+	m = NewModel(cfg)
+	for range 4 {
+		tick()
+	}
+	assert.Equal(t, "Talk +1.0", m.PlainView())
+	assert.False(t, m.done)
+	tick()
+	tick()
+	assert.Equal(t, "Talk +2.0", m.PlainView(), "Overtime should stop at the maximum")
+	assert.True(t, m.done)
+	assert.Equal(t, EventOvertimeEnded, events[len(events)-1].Type)
+}
+
+func TestFormatClock(t *testing.T) {
+	tests := []struct {
+		name    string
+		seconds int
+		want    string
+	}{
+		{"zero", 0, "00:00:00"},
+		{"seconds", 59, "00:00:59"},
+		{"minutes", 90, "00:01:30"},
+		{"hours", 4500, "01:15:00"},
+		{"many hours", 360000, "100:00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatClock(tt.seconds))
+		})
+	}
+}
+
+func TestModelFormatCount(t *testing.T) {
+	tests := []struct {
+		name           string
+		start          int
+		end            int
+		current        int
+		format         Format
+		precision      int
+		precisionFinal bool
+		want           string
+	}{
+		{"plain number", 10, 0, 7, FormatNumber, 0, false, "7"},
+		{"negative number", 10, -10, -7, FormatNumber, 0, false, "-7"},
+		{"tenths", 100, 0, 47, FormatNumber, 1, false, "4.7"},
+		{"hundredths", 1000, 0, 5, FormatNumber, 2, false, "0.05"},
+		{"negative tenths", 100, -100, -3, FormatNumber, 1, false, "-0.3"},
+		{"clock with tenths", 9000, 0, 8995, FormatClock, 1, false, "00:14:59.5"},
+		{"decimals only in final phase", 100, 0, 47, FormatNumber, 1, true, "4.7"},
+		{"rounds up before final phase", 1000, 0, 147, FormatNumber, 1, true, "15"},
+		{"whole before final phase", 1000, 0, 140, FormatNumber, 1, true, "14"},
+		{"rounds down counting up", 0, 1000, 147, FormatNumber, 1, true, "14"},
+		{"clock before final phase", 9000, 0, 8995, FormatClock, 1, true, "00:15:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{
+				config: Config{
+					Start:          tt.start,
+					End:            tt.end,
+					FinalPhase:     50,
+					Format:         tt.format,
+					Precision:      tt.precision,
+					PrecisionFinal: tt.precisionFinal,
+				},
+				current: tt.current,
+			}
+			if tt.end > tt.start {
+				m.config.FinalPhase = tt.end - 50
+			}
+
+			assert.Equal(t, tt.want, m.formatCount())
+		})
+	}
+}
+
+func TestModelViewWithBigDecimal(t *testing.T) {
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        47,
+		End:          0,
+		TimeInterval: 100 * time.Millisecond,
+		Decrement:    1,
+		Precision:    1,
+		Big:          true,
+	}
+
+	view := NewModel(cfg).View()
+	assert.Contains(t, view, "╭─╮", "Big decimals should render the decimal point glyph")
+}
+
+func TestModelViewWithClockFormat(t *testing.T) {
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        90,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		FinalPhase:   5,
+		Format:       FormatClock,
+	}
+
+	m := NewModel(cfg)
+	assert.Contains(t, m.View(), "00:01:30")
+
+	m.config.Big = true
+	view := m.View()
+	for _, line := range bigDigits[':'][1:5] {
+		assert.Contains(t, view, line, "Big clock should render the colon glyph")
+	}
+}
+
+func TestModelUpdateTicks(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        5,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		Clock:        clock,
+	}
+
+	m := NewModel(cfg)
+	for want := 4; want > 0; want-- {
+		clock.Advance(time.Second)
+		updated, cmd := m.Update(tickMsg{})
+		m = updated.(Model)
+		assert.Equal(t, want, m.current)
+		assert.NotNil(t, cmd)
+	}
+
+	clock.Advance(time.Second)
+	updated, _ := m.Update(tickMsg{})
+	m = updated.(Model)
+	assert.True(t, m.done)
+	assert.Equal(t, 0, m.current)
+}
+
+func TestModelUpdateCatchesUpAfterSuspend(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        100,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		Clock:        clock,
+	}
+
+	m := NewModel(cfg)
+
+	// Stopped with Ctrl+Z for 42 seconds
+	clock.Advance(42*time.Second + 300*time.Millisecond)
+	updated, _ := m.Update(tea.ResumeMsg{})
+	m = updated.(Model)
+	assert.Equal(t, 58, m.current)
+	assert.False(t, m.done)
+
+	// Asleep for longer than the remaining count
+	clock.Advance(time.Hour)
+	updated, cmd := m.Update(tickMsg{})
+	m = updated.(Model)
+	assert.True(t, m.done)
+	assert.NotNil(t, cmd)
+}
+
+// pressKey sends a key press to the model.
+func pressKey(m Model, k string) (Model, tea.Cmd) {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	if k == " " {
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(k)}
+	}
+	updated, cmd := m.Update(msg)
+	return updated.(Model), cmd
+}
+
+func TestModelKeyControls(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        60,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		FinalPhase:   5,
+		Adjust:       10,
+		Clock:        clock,
+	}
+
+	m := NewModel(cfg)
+	clock.Advance(3 * time.Second)
+
+	// Pause freezes the count and drops the tick in flight
+	m, cmd := pressKey(m, " ")
+	assert.Nil(t, cmd)
+	assert.Equal(t, 57, m.current)
+	assert.Contains(t, m.View(), "(paused)")
+	clock.Advance(time.Minute)
+	updated, cmd := m.Update(tickMsg{tag: m.tickTag})
+	m = updated.(Model)
+	assert.Nil(t, cmd)
+	assert.Equal(t, 57, m.current)
+
+	// Resume starts a new chain of ticks
+	m, cmd = pressKey(m, " ")
+	assert.NotNil(t, cmd)
+	assert.NotContains(t, m.View(), "(paused)")
+	updated, cmd = m.Update(tickMsg{tag: m.tickTag - 1})
+	assert.Nil(t, cmd, "Ticks from before the pause should be dropped")
+	m = updated.(Model)
+
+	m, _ = pressKey(m, "+")
+	assert.Equal(t, 67, m.current)
+	m, _ = pressKey(m, "-")
+	m, _ = pressKey(m, "-")
+	assert.Equal(t, 47, m.current)
+
+	m, _ = pressKey(m, "s")
+	assert.Equal(t, 5, m.current)
+	assert.True(t, m.isInFinalPhase())
+
+	m, cmd = pressKey(m, "r")
+	assert.Equal(t, 60, m.current)
+	assert.NotNil(t, cmd)
+
+	m, cmd = pressKey(m, "-")
+	assert.Equal(t, 50, m.current)
+	assert.Nil(t, cmd)
+	for range 5 {
+		m, cmd = pressKey(m, "-")
+	}
+	assert.True(t, m.done, "Subtracting past the end should finish the countdown")
+	assert.NotNil(t, cmd)
+}
+
+func TestModelOutcome(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        3,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		Clock:        clock,
+	}
+
+	m, cmd := pressKey(NewModel(cfg), "q")
+	assert.NotNil(t, cmd)
+	assert.True(t, m.aborted)
+	assert.False(t, m.completed)
+
+	m = NewModel(cfg)
+	updated, _ := m.Update(shutdownMsg{signal: syscall.SIGTERM})
+	m = updated.(Model)
+	assert.True(t, m.killed)
+	assert.Equal(t, syscall.SIGTERM, m.signal)
+
+	updated, cmd = NewModel(cfg).Update(StopMsg{})
+	m = updated.(Model)
+	assert.True(t, m.stopped)
+	assert.True(t, m.done)
+	assert.NotNil(t, cmd)
+	assert.Empty(t, m.View())
+
+	m = NewModel(cfg)
+	clock.Advance(3 * time.Second)
+	updated, _ = m.Update(tickMsg{})
+	m = updated.(Model)
+	assert.True(t, m.completed)
+	assert.False(t, m.aborted)
+}
+
+func TestModelOnSignal(t *testing.T) {
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        30,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+	}
+
+	t.Run("show", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+		cfg := cfg
+		cfg.Clock = clock
+		cfg.OnSignal = SignalShow
+
+		updated, cmd := NewModel(cfg).Update(shutdownMsg{signal: syscall.SIGHUP})
+		m := updated.(Model)
+		assert.True(t, m.killed)
+		assert.Contains(t, m.View(), "(killed)", "The killed frame should stay on screen")
+		require.NotNil(t, cmd)
+
+		msgs := make(chan tea.Msg, 1)
+		go func() { msgs <- cmd() }()
+		clock.BlockUntil(1)
+		clock.Advance(killedLinger)
+		assert.Equal(t, tea.QuitMsg{}, <-msgs)
+	})
+
+	t.Run("exit", func(t *testing.T) {
+		cfg := cfg
+		cfg.OnSignal = SignalExit
+
+		updated, cmd := NewModel(cfg).Update(shutdownMsg{signal: syscall.SIGTERM})
+		m := updated.(Model)
+		assert.True(t, m.killed)
+		assert.Equal(t, syscall.SIGTERM, m.signal)
+		require.NotNil(t, cmd)
+		assert.Equal(t, tea.QuitMsg{}, cmd())
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		cfg := cfg
+		cfg.OnSignal = SignalIgnore
+
+		updated, cmd := NewModel(cfg).Update(shutdownMsg{signal: syscall.SIGTERM})
+		m := updated.(Model)
+		assert.False(t, m.killed)
+		assert.Nil(t, cmd)
+		assert.NotContains(t, m.View(), "(killed)")
+	})
+}
+
+func TestModelPauseAndRestartMessages(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        30,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		Clock:        clock,
+	}
+
+	m := NewModel(cfg)
+	clock.Advance(5 * time.Second)
+
+	updated, _ := m.Update(pauseMsg{})
+	m = updated.(Model)
+	assert.True(t, m.engine.paused())
+	assert.Equal(t, 25, m.current)
+
+	updated, cmd := m.Update(pauseMsg{})
+	m = updated.(Model)
+	assert.False(t, m.engine.paused())
+	assert.NotNil(t, cmd)
+
+	updated, _ = m.Update(restartMsg{})
+	m = updated.(Model)
+	assert.Equal(t, 30, m.current)
+}
+
+func TestModelHelpOverlay(t *testing.T) {
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        10,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+	}
+
+	m := NewModel(cfg)
+	assert.NotContains(t, m.View(), "pause/resume")
+
+	m, _ = pressKey(m, "?")
+	view := m.View()
+	assert.Contains(t, view, "pause/resume")
+	assert.Contains(t, view, "skip to final phase")
+
+	m, _ = pressKey(m, "?")
+	assert.NotContains(t, m.View(), "pause/resume")
+}
+
+func TestModelUpdateWithDeadline(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	cfg := Config{
+		SpinnerType:  "none",
+		Title:        "Test",
+		Start:        10,
+		End:          0,
+		TimeInterval: 2 * time.Second,
+		Decrement:    2,
+		Format:       FormatClock,
+		Deadline:     clock.Now().Add(9500 * time.Millisecond),
+		Clock:        clock,
+	}
+
+	m := NewModel(cfg)
+
+	// Steps fall on whole intervals before the deadline
+	assert.Equal(t, 1500*time.Millisecond, m.engine.untilNext(clock.Now()))
+
+	clock.Advance(1500 * time.Millisecond)
+	updated, _ := m.Update(tickMsg{})
+	m = updated.(Model)
+	assert.Equal(t, 8, m.current)
+
+	clock.Advance(time.Second)
+	updated, _ = m.Update(tickMsg{})
+	m = updated.(Model)
+	assert.Equal(t, 8, m.current, "The count should only change once an interval has passed")
+
+	clock.Advance(7 * time.Second)
+	updated, _ = m.Update(tickMsg{})
+	m = updated.(Model)
+	assert.True(t, m.done, "Countdown should finish at the deadline")
+	assert.Equal(t, 0, m.current)
+}
+
+func TestModelRestartWithDeadline(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	cfg := Config{
+		Start:    10,
+		End:      0,
+		Adjust:   5,
+		Deadline: clock.Now().Add(10 * time.Second),
+		Clock:    clock,
+	}
+	m := NewModel(cfg)
+
+	clock.Advance(3 * time.Second)
+	m, _ = pressKey(m, "+")
+	assert.Equal(t, 12, m.current)
+
+	updated, _ := m.Update(restartMsg{})
+	m = updated.(Model)
+	assert.Equal(t, 7, m.current, "Restarting should count down to the deadline again, not for as long from now")
+	assert.Equal(t, cfg.Deadline, m.engine.endsAt())
+}
+
 func TestModelViewWithBig(t *testing.T) {
 	cfg := Config{
 		SpinnerType:  "none",
 		Title:        "Test",
 		Start:        10,
 		End:          0,
-		TimeInterval: 1,
+		TimeInterval: time.Second,
 		Decrement:    1,
 		FinalPhase:   2,
 		Big:          true,
@@ -287,7 +820,7 @@ func TestModelViewWithBigDisabled(t *testing.T) {
 		Title:        "Test",
 		Start:        10,
 		End:          0,
-		TimeInterval: 1,
+		TimeInterval: time.Second,
 		Decrement:    1,
 		FinalPhase:   2,
 		Big:          false,
@@ -0,0 +1,108 @@
+package countdown
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Printer prints output, such as that of a wrapped command, above a running
+// countdown a line at a time, so that it isn't mixed into the count as it is
+// redrawn. While no countdown is running it writes straight to the writer it
+// was made with, and in fullscreen, where there is nowhere above to print,
+// the output is held until the countdown ends. It is safe for concurrent use.
+type Printer struct {
+	mu  sync.Mutex
+	out io.Writer
+	// program prints the lines while a countdown is running, until finished
+	// is closed.
+	program  *tea.Program
+	finished chan struct{}
+	// held is output waiting for the end of a line or of the countdown.
+	held []byte
+	hold bool
+}
+
+// NewPrinter returns a printer which writes to out while no countdown is
+// running.
+func NewPrinter(out io.Writer) *Printer {
+	return &Printer{out: out}
+}
+
+// Write prints the complete lines of b above the countdown, keeping the rest
+// for later.
+func (p *Printer) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.held = append(p.held, b...)
+	if p.program == nil {
+		return len(b), p.flush()
+	}
+	if p.hold {
+		return len(b), nil
+	}
+	for {
+		i := bytes.IndexByte(p.held, '\n')
+		if i < 0 {
+			return len(b), nil
+		}
+		line := string(p.held[:i])
+		if !p.println(line) {
+			// The countdown has ended, so the rest goes straight out
+			return len(b), p.flush()
+		}
+		p.held = p.held[i+1:]
+	}
+}
+
+// println prints a line above the countdown, and reports false if it ended
+// first. Program.Println waits for the program to take the line, which it
+// never does once it has ended, so only its return is waited for.
+func (p *Printer) println(line string) bool {
+	printed := make(chan struct{})
+	go func() {
+		p.program.Println(line)
+		close(printed)
+	}()
+	select {
+	case <-printed:
+		return true
+	case <-p.finished:
+		select {
+		case <-printed:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// flush writes out all held output.
+func (p *Printer) flush() error {
+	held := p.held
+	p.held = nil
+	_, err := p.out.Write(held)
+	return err
+}
+
+// attach starts printing above the program, or holding the output with hold.
+func (p *Printer) attach(program *tea.Program, hold bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.program, p.finished, p.hold = program, make(chan struct{}), hold
+}
+
+// detach stops printing above the program once it has ended, and writes out
+// any output held while it ran.
+func (p *Printer) detach() error {
+	// Let a line waiting to be printed give up before taking the lock
+	close(p.finished)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.program = nil
+	return p.flush()
+}
@@ -0,0 +1,88 @@
+package countdown
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runPrinting runs the countdown, writing to the printer once it has started
+// in separate parts, and then stopping it. It returns what was drawn.
+func runPrinting(t *testing.T, cfg Config, printer *Printer, parts ...string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	stop := make(chan struct{})
+	started := make(chan struct{})
+	cfg.Output, cfg.Input = &out, strings.NewReader("")
+	cfg.Stop, cfg.Printers = stop, []*Printer{printer}
+	cfg.OnEvent = func(e Event) {
+		if e.Type == EventStart {
+			close(started)
+		}
+	}
+
+	go func() {
+		<-started
+		for _, part := range parts {
+			_, err := printer.Write([]byte(part))
+			assert.NoError(t, err)
+		}
+		close(stop)
+	}()
+
+	result, err := Run(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, Stopped, result.Outcome)
+	return ansi.Strip(out.String())
+}
+
+func TestPrinterPrintsAboveCountdown(t *testing.T) {
+	cfg := Config{SpinnerType: "none", Title: "Test", Start: 60, End: 0, TimeInterval: time.Second, Decrement: 1}
+	var direct bytes.Buffer
+	printer := NewPrinter(&direct)
+
+	drawn := runPrinting(t, cfg, printer, "child ", "output\nand more\nunfinished")
+
+	var printed []string
+	for _, line := range strings.Split(drawn, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "child") || strings.Contains(line, "more") {
+			printed = append(printed, line)
+		}
+	}
+	assert.Equal(t, []string{"child output", "and more"}, printed, "Each line should be printed whole, apart from the count")
+	assert.Equal(t, "unfinished", direct.String(), "The rest should be written once the countdown ends")
+
+	_, err := printer.Write([]byte("after\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "unfinishedafter\n", direct.String(), "Output after the countdown should be written straight out")
+}
+
+func TestPrinterHoldsOutputInFullscreen(t *testing.T) {
+	cfg := Config{SpinnerType: "none", Title: "Test", Start: 60, End: 0, TimeInterval: time.Second, Decrement: 1, Fullscreen: true}
+	var direct bytes.Buffer
+	printer := NewPrinter(&direct)
+
+	drawn := runPrinting(t, cfg, printer, "child output\n")
+	assert.NotContains(t, drawn, "child output")
+	assert.Equal(t, "child output\n", direct.String())
+}
+
+func TestPrinterWithoutCountdown(t *testing.T) {
+	var direct bytes.Buffer
+	printer := NewPrinter(&direct)
+	n, err := printer.Write([]byte("partial"))
+	require.NoError(t, err)
+	assert.Equal(t, 7, n)
+	assert.Equal(t, "partial", direct.String())
+
+	_, err = NewPrinter(io.Discard).Write(nil)
+	assert.NoError(t, err)
+}
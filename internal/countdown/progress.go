@@ -0,0 +1,93 @@
+package countdown
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/charmbracelet/bubbles/progress"
+)
+
+// Display selects what shows how far the countdown has got. The values can
+// be combined, such as DisplayNumber|DisplayBar.
+type Display int
+
+const (
+	// DisplayNumber shows the count itself.
+	DisplayNumber Display = 1 << iota
+	// DisplayBar shows a progress bar from the start to the end.
+	DisplayBar
+	// DisplayPercent shows how far the count has got as a percentage.
+	DisplayPercent
+)
+
+// Widths of the progress bar, in cells. Without a terminal width the default
+// is used; with one the bar fills what is left of it, up to the maximum.
+const (
+	defaultBarWidth = 40
+	maxBarWidth     = 80
+)
+
+// newProgressBar returns a progress bar filled with one color, a gradient
+// between two, or the default gradient with none.
+func newProgressBar(colors []string) progress.Model {
+	opts := []progress.Option{progress.WithoutPercentage()}
+	switch len(colors) {
+	case 0:
+		opts = append(opts, progress.WithDefaultGradient())
+	case 1:
+		opts = append(opts, progress.WithSolidFill(hexColor(colors[0])))
+	default:
+		opts = append(opts, progress.WithGradient(hexColor(colors[0]), hexColor(colors[1])))
+	}
+	return progress.New(opts...)
+}
+
+// hexColor returns a color string, which may be an ANSI number or name, as a
+// hex code for blending.
+func hexColor(s string) string {
+	r, g, b := colorToRGB(s)
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// shows reports whether Config.Display includes d.
+func (m Model) shows(d Display) bool {
+	return m.config.Display&d != 0
+}
+
+// percent returns how far the count has got from the start to the end, from
+// 0 to 1.
+func (m Model) percent() float64 {
+	if m.config.Endless {
+		return 0
+	}
+	total := m.config.End - m.config.Start
+	if total == 0 {
+		return 1
+	}
+	// Adjustments can take the count back past the start, and overtime takes
+	// it on past the end
+	return min(max(float64(m.current-m.config.Start)/float64(total), 0), 1)
+}
+
+// formatPercent returns a fraction from 0 to 1 as a whole percentage, rounded
+// down so that 100% is only shown at the end.
+func formatPercent(p float64) string {
+	return fmt.Sprintf("%d%%", int(math.Floor(p*100+1e-9)))
+}
+
+// renderBar renders the progress bar filled to p, from 0 to 1.
+func (m Model) renderBar(p float64, width int) string {
+	bar := m.bar
+	bar.Width = width
+	return bar.ViewAs(p)
+}
+
+// barWidth returns the width of a progress bar drawn after used cells of a
+// line, keeping within the terminal and the container's padding.
+func (m Model) barWidth(used int) int {
+	if m.width == 0 {
+		return defaultBarWidth
+	}
+	available := m.width - m.containerStyle.GetHorizontalFrameSize() - used
+	return max(min(available, maxBarWidth), 1)
+}
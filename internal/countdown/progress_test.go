@@ -0,0 +1,105 @@
+package countdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelPercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		current int
+		want    float64
+	}{
+		{"at start", Config{Start: 3600, End: 0}, 3600, 0},
+		{"counting down", Config{Start: 3600, End: 0}, 900, 0.75},
+		{"counting up", Config{Start: 0, End: 200}, 50, 0.25},
+		{"back past the start", Config{Start: 100, End: 0}, 120, 0},
+		{"overtime", Config{Start: 100, End: 0, Overtime: true}, -20, 1},
+		{"no range", Config{Start: 5, End: 5}, 5, 1},
+		{"endless", Config{Start: 0, Endless: true}, 500, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel(tt.cfg)
+			m.current = tt.current
+			assert.InDelta(t, tt.want, m.percent(), 0.0001)
+		})
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	assert.Equal(t, "0%", formatPercent(0))
+	assert.Equal(t, "29%", formatPercent(0.29))
+	assert.Equal(t, "99%", formatPercent(0.999), "100% should only be shown at the end")
+	assert.Equal(t, "100%", formatPercent(1))
+}
+
+func TestViewDisplay(t *testing.T) {
+	cfg := Config{
+		SpinnerType: "none",
+		Title:       "Liftoff in",
+		Start:       100,
+		End:         0,
+		FinalPhase:  5,
+	}
+	view := func(cfg Config, width int) []string {
+		m := NewModel(cfg)
+		m.current = 75
+		if width > 0 {
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: 24})
+			m = updated.(Model)
+		}
+		return strings.Split(m.View(), "\n")
+	}
+
+	lines := view(cfg, 0)
+	assert.Equal(t, []string{" Liftoff in 75"}, lines, "The number alone should show by default")
+
+	cfg.Display = DisplayNumber | DisplayPercent
+	assert.Equal(t, []string{" Liftoff in 75 25%"}, view(cfg, 0))
+
+	cfg.Display = DisplayNumber | DisplayBar
+	lines = view(cfg, 0)
+	if assert.Len(t, lines, 2, "The bar should be beneath the count") {
+		assert.Equal(t, defaultBarWidth, lipgloss.Width(lines[1]))
+	}
+
+	cfg.PaddingHorizontal = 3
+	lines = view(cfg, 50)
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, 50, lipgloss.Width(lines[1]), "The bar should fill the terminal inside the padding")
+	}
+
+	cfg.PaddingHorizontal = 0
+	cfg.Display = DisplayBar
+	cfg.ProgressBeside = true
+	lines = view(cfg, 60)
+	if assert.Len(t, lines, 1, "The bar should be beside the title") {
+		assert.True(t, strings.HasPrefix(lines[0], " Liftoff in "))
+		assert.Equal(t, 60, lipgloss.Width(lines[0]))
+		assert.NotContains(t, lines[0], "75")
+	}
+
+	cfg.Big = true
+	cfg.Display = DisplayNumber | DisplayBar
+	lines = view(cfg, 60)
+	assert.Len(t, lines, 7, "The big number should be beneath the title and bar")
+	assert.Equal(t, 60, lipgloss.Width(lines[0]))
+}
+
+func TestPlainViewDisplay(t *testing.T) {
+	m := NewModel(Config{Title: "Upload", Start: 0, End: 200, Display: DisplayBar, Clock: NewFakeClock(time.Now())})
+	m.current = 50
+	assert.Equal(t, "Upload 25%", m.PlainView(), "A bar should be shown as a percentage")
+
+	m.config.Display = DisplayNumber | DisplayPercent
+	assert.Equal(t, "Upload 50 25%", m.PlainView())
+}
@@ -0,0 +1,201 @@
+package countdown
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Outcome describes how a countdown ended.
+type Outcome int
+
+const (
+	// Completed means the count reached its end.
+	Completed Outcome = iota
+	// Aborted means the user quit before the end.
+	Aborted
+	// Killed means the process received a shutdown signal.
+	Killed
+	// Stopped means the countdown was ended early by a StopMsg.
+	Stopped
+)
+
+// Result reports how a countdown ended.
+type Result struct {
+	Outcome Outcome
+	// Signal is the signal which was received when the outcome is Killed.
+	Signal os.Signal
+	// Count is the count when the countdown ended, as displayed.
+	Count string
+	// Laps are those recorded with Config.Endless, in order.
+	Laps []Lap
+}
+
+// Run starts the countdown application and reports how it ended. With
+// Config.Plain the count is printed line by line instead of drawn on the
+// terminal, driven by the same model.
+func Run(cfg Config) (Result, error) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, slices.Concat(shutdownSignals, pauseSignals, restartSignals)...)
+	defer signal.Stop(sigChan)
+
+	out := cfg.Output
+	if out == nil {
+		out = os.Stdout
+	}
+	if cfg.Plain {
+		return runPlain(NewModel(cfg), out, sigChan, cfg.Stop)
+	}
+	if out != os.Stdout {
+		// Pick colors for the terminal actually being drawn on
+		lipgloss.SetDefaultRenderer(lipgloss.NewRenderer(out))
+	}
+
+	if _, ok := out.(*os.File); !ok {
+		// Bells are written alongside Bubble Tea's renderer, so take turns
+		out = &lockedWriter{w: out}
+	}
+
+	// Signals are handled here rather than by Bubble Tea so that
+	// Config.OnSignal decides what they do
+	opts := []tea.ProgramOption{tea.WithoutSignalHandler(), tea.WithOutput(out), tea.WithFilter(ringBells(out))}
+	if cfg.Input != nil {
+		opts = append(opts, tea.WithInput(cfg.Input))
+	}
+	if cfg.Fullscreen {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	p := tea.NewProgram(NewModel(cfg), opts...)
+
+	go func() {
+		for sig := range sigChan {
+			p.Send(signalMsg(sig))
+		}
+	}()
+
+	if cfg.Stop != nil {
+		go func() {
+			<-cfg.Stop
+			p.Send(StopMsg{})
+		}()
+	}
+
+	for _, printer := range cfg.Printers {
+		printer.attach(p, cfg.Fullscreen)
+	}
+	final, err := p.Run()
+	for _, printer := range cfg.Printers {
+		if perr := printer.detach(); err == nil {
+			err = perr
+		}
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	m, _ := final.(Model)
+	return m.result(), nil
+}
+
+// ringBells returns a filter for the program which writes the terminal bell
+// to out for each bellMsg, as Bubble Tea has no command for it.
+func ringBells(out io.Writer) func(tea.Model, tea.Msg) tea.Msg {
+	return func(_ tea.Model, msg tea.Msg) tea.Msg {
+		if _, ok := msg.(bellMsg); ok {
+			_, _ = io.WriteString(out, "\a")
+			return nil
+		}
+		return msg
+	}
+}
+
+// lockedWriter is a writer which can be written to from more than one
+// goroutine.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write writes b once no other write is in progress.
+func (l *lockedWriter) Write(b []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(b)
+}
+
+// runPlain drives the model without Bubble Tea, printing a line whenever the
+// text changes.
+func runPlain(m Model, out io.Writer, signals <-chan os.Signal, stop <-chan struct{}) (Result, error) {
+	m.start()
+	last := ""
+	emit := func() error {
+		line := m.PlainView()
+		if line == last {
+			return nil
+		}
+		last = line
+		_, err := fmt.Fprintln(out, line)
+		return err
+	}
+
+	if err := emit(); err != nil {
+		return Result{}, err
+	}
+	for !m.done && !m.killed {
+		var msg tea.Msg
+		var tick <-chan time.Time
+		if !m.engine.paused() {
+			tick = m.clock.After(m.engine.untilNext(m.clock.Now()))
+		}
+
+		select {
+		case <-tick:
+			msg = tickMsg{tag: m.tickTag}
+		case sig := <-signals:
+			msg = signalMsg(sig)
+		case <-stop:
+			msg = StopMsg{}
+			stop = nil
+		}
+
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+		if err := emit(); err != nil {
+			return Result{}, err
+		}
+	}
+	return m.result(), nil
+}
+
+// signalMsg returns the message for a signal received by the process.
+func signalMsg(sig os.Signal) tea.Msg {
+	switch {
+	case slices.Contains(pauseSignals, sig):
+		return pauseMsg{}
+	case slices.Contains(restartSignals, sig):
+		return restartMsg{}
+	default:
+		return shutdownMsg{signal: sig}
+	}
+}
+
+// result reports how the countdown ended.
+func (m Model) result() Result {
+	r := Result{Outcome: Aborted, Count: m.formatCount(), Laps: m.laps}
+	switch {
+	case m.killed:
+		r.Outcome, r.Signal = Killed, m.signal
+	case m.completed:
+		r.Outcome = Completed
+	case m.stopped:
+		r.Outcome = Stopped
+	}
+	return r
+}
@@ -0,0 +1,127 @@
+package countdown
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// plainRun is a countdown being driven by runPlain in the background.
+type plainRun struct {
+	clock   *FakeClock
+	out     *bytes.Buffer
+	signals chan<- os.Signal
+	stop    chan struct{}
+	result  chan Result
+}
+
+func startPlain(t *testing.T, cfg Config) plainRun {
+	t.Helper()
+
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	cfg.Clock = clock
+	signals := make(chan os.Signal, 1)
+	r := plainRun{
+		clock:   clock,
+		out:     &bytes.Buffer{},
+		signals: signals,
+		stop:    make(chan struct{}),
+		result:  make(chan Result, 1),
+	}
+
+	go func() {
+		result, err := runPlain(NewModel(cfg), r.out, signals, r.stop)
+		assert.NoError(t, err)
+		r.result <- result
+	}()
+	return r
+}
+
+// step advances the clock to the next tick once runPlain is waiting for it.
+func (r plainRun) step(d time.Duration) {
+	r.clock.BlockUntil(1)
+	r.clock.Advance(d)
+}
+
+func TestRunPlain(t *testing.T) {
+	cfg := Config{
+		Title:        "Liftoff in",
+		Start:        3,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		FinalPhase:   1,
+	}
+
+	r := startPlain(t, cfg)
+	for range 3 {
+		r.step(time.Second)
+	}
+
+	assert.Equal(t, Result{Outcome: Completed, Count: "0"}, <-r.result)
+	assert.Equal(t, "Liftoff in 3\nLiftoff in 2\nLiftoff in 1\nLiftoff in 0\n", r.out.String())
+	assert.NotContains(t, r.out.String(), "\x1b", "Plain output should have no escape sequences")
+}
+
+func TestRunPlainOnlyPrintsChanges(t *testing.T) {
+	cfg := Config{
+		Title:          "T-minus",
+		Start:          20,
+		End:            0,
+		TimeInterval:   100 * time.Millisecond,
+		Decrement:      1,
+		Precision:      1,
+		PrecisionFinal: true,
+		FinalPhase:     5,
+	}
+
+	r := startPlain(t, cfg)
+	for range 20 {
+		r.step(100 * time.Millisecond)
+	}
+
+	<-r.result
+	lines := strings.Split(strings.TrimSpace(r.out.String()), "\n")
+	assert.Equal(t, []string{"T-minus 2", "T-minus 1", "T-minus 0.5", "T-minus 0.4", "T-minus 0.3", "T-minus 0.2", "T-minus 0.1", "T-minus 0.0"}, lines)
+}
+
+func TestRunPlainSignals(t *testing.T) {
+	cfg := Config{
+		Title:        "Test",
+		Start:        10,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+	}
+
+	r := startPlain(t, cfg)
+	r.step(time.Second)
+	r.signals <- syscall.SIGTERM
+
+	result := <-r.result
+	assert.Equal(t, Killed, result.Outcome)
+	assert.Equal(t, syscall.SIGTERM, result.Signal)
+	assert.Contains(t, r.out.String(), "Test (killed) 9\n")
+}
+
+func TestRunPlainStop(t *testing.T) {
+	cfg := Config{
+		Title:        "Test",
+		Start:        10,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+	}
+
+	r := startPlain(t, cfg)
+	r.step(time.Second)
+	close(r.stop)
+
+	assert.Equal(t, Result{Outcome: Stopped, Count: "9"}, <-r.result)
+	assert.Equal(t, "Test 10\nTest 9\n", r.out.String())
+}
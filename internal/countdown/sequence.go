@@ -0,0 +1,169 @@
+package countdown
+
+import (
+	"cmp"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Segment is one part of a sequence, such as the work or the break of a
+// pomodoro.
+type Segment struct {
+	// Label replaces the title while the segment counts down, when set.
+	Label string
+	// Length is how far the segment counts down, in the same units as
+	// Config.Start.
+	Length int
+	// Theme replaces Config.Theme for the segment, when it has a name.
+	Theme Theme
+	// Deadline, when set, makes the segment count down the time remaining
+	// until that instant, as Config.Deadline does, and Length is ignored.
+	Deadline time.Time
+	// FinalPhase and Stages replace those in Config for the segment, so that
+	// they can be worked out from its length.
+	FinalPhase int
+	Stages     []Stage
+	// Thresholds, when set, works out FinalPhase and Stages again from the
+	// length of a segment with a Deadline, which is only known once it
+	// begins.
+	Thresholds func(length int) (finalPhase int, stages []Stage)
+}
+
+// progressWidth is the number of cells in the sequence's progress bar.
+const progressWidth = 20
+
+// segmentConfig returns the configuration for counting down segment i of the
+// sequence on its own, beginning at the given time.
+func (cfg Config) segmentConfig(i int, at time.Time) Config {
+	seg := cfg.Sequence[i]
+	if !seg.Deadline.IsZero() {
+		unit := time.Second / time.Duration(Scale(cfg.Precision))
+		seg.Length = max(int((seg.Deadline.Sub(at)+unit-1)/unit), 0)
+		cfg.Deadline = seg.Deadline
+		if seg.Thresholds != nil {
+			seg.FinalPhase, seg.Stages = seg.Thresholds(seg.Length)
+		}
+	}
+	if i < len(cfg.Sequence)-1 {
+		// Only the last segment can run into overtime
+		cfg.Overtime = false
+	}
+	cfg.Sequence = nil
+	cfg.Start, cfg.End = seg.Length, 0
+	cfg.FinalPhase, cfg.Stages = seg.FinalPhase, seg.Stages
+	if seg.Label != "" {
+		cfg.Title = seg.Label
+	}
+	if seg.Theme.Name != "" {
+		cfg.Theme = seg.Theme
+	}
+	return cfg
+}
+
+// toSegment returns the model for segment i of the sequence, beginning at
+// the given time. Pausing, help and the tick chain carry on from m, so that
+// the sequence runs as one countdown.
+func (m Model) toSegment(i int, at time.Time) Model {
+	next := NewModel(m.base.segmentConfig(i, at))
+	next.base, next.segment = m.base, i
+	next.clock, next.keys = m.clock, m.keys
+	if next.config.Deadline.IsZero() {
+		next.engine = newEngine(next.config.Start, next.config.End, next.config.Decrement, next.config.TimeInterval, at)
+		next.engine.overtime = next.config.Overtime
+	}
+	if m.engine.paused() {
+		next.engine.pause(maxTime(m.engine.pausedAt, at))
+	}
+	next.tickTag, next.showHelp = m.tickTag, m.showHelp
+	next.rung = m.rung
+	next.width, next.height = m.width, m.height
+	next.killed, next.signal = m.killed, m.signal
+	return next
+}
+
+// moveSegment moves by delta segments through the sequence from the current
+// one, which starts afresh now. Moving back from the first segment restarts
+// it, and moving on from the last does nothing.
+func (m Model) moveSegment(delta int) (tea.Model, tea.Cmd) {
+	i := max(m.segment+delta, 0)
+	if i >= len(m.base.Sequence) {
+		return m, nil
+	}
+	m = m.toSegment(i, m.clock.Now())
+	m.emit(EventSegment)
+	if m.inFinalPhase {
+		m.emit(EventFinalPhaseEntered)
+	}
+	if m.advance() {
+		return m, tea.Quit
+	}
+	return m, m.restartTicks()
+}
+
+// agendaTitle returns the title for a segment with Config.Agenda, such as
+// "Now: Keynote — Next: Lunch", after any Config.AgendaTitle.
+func (m Model) agendaTitle(title string) string {
+	title = "Now: " + title
+	if next := m.segment + 1; next < len(m.base.Sequence) {
+		title += " — Next: " + cmp.Or(m.base.Sequence[next].Label, m.base.Title)
+	}
+	if m.base.AgendaTitle != "" {
+		title = m.base.AgendaTitle + " — " + title
+	}
+	return title
+}
+
+// maxTime returns the later of a and b.
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// inSequence reports whether the countdown is a sequence of segments.
+func (m Model) inSequence() bool {
+	return len(m.base.Sequence) > 0
+}
+
+// sequenceProgress returns how far through the whole sequence the count has
+// got, from 0 to 1.
+func (m Model) sequenceProgress() float64 {
+	total, done := 0, 0
+	for i, seg := range m.base.Sequence {
+		total += seg.Length
+		if i < m.segment {
+			done += seg.Length
+		}
+	}
+	if total == 0 {
+		return 1
+	}
+	done += min(max(m.config.Start-m.current, 0), m.config.Start)
+	return float64(done) / float64(total)
+}
+
+// withSequence adds a bar showing the progress through the whole sequence
+// beneath the content.
+func (m Model) withSequence(content string) string {
+	if !m.inSequence() {
+		return content
+	}
+	progress := m.sequenceProgress()
+	return content + "\n" + m.renderBar(progress, progressWidth) + m.titleStyle.Render(" "+formatPercent(progress))
+}
+
+// bell rings the terminal bell, which Update does once the model is done
+// with.
+func (m *Model) bell() {
+	m.rung++
+}
+
+// bellMsg is sent to ring the terminal bell, which Run writes to the display.
+type bellMsg struct{}
+
+// ringBell is a command ringing the terminal bell.
+func ringBell() tea.Msg {
+	return bellMsg{}
+}
@@ -0,0 +1,204 @@
+package countdown
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelSequence(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	var events []Event
+	dracula, _ := LookupTheme("dracula")
+	m := NewModel(Config{
+		SpinnerType:  "none",
+		Title:        "Pomodoro",
+		TimeInterval: time.Second,
+		Decrement:    1,
+		Clock:        clock,
+		OnEvent:      func(e Event) { events = append(events, e) },
+		Sequence: []Segment{
+			{Label: "Work", Length: 3},
+			{Label: "Break", Length: 2, Theme: dracula},
+			{Length: 2},
+		},
+	})
+	tick := func(d time.Duration) tea.Cmd {
+		clock.Advance(d)
+		updated, cmd := m.Update(tickMsg{tag: m.tickTag})
+		m = updated.(Model)
+		return cmd
+	}
+
+	assert.Equal(t, "Work 1/3 3", m.PlainView())
+	assert.Contains(t, m.View(), "0%")
+
+	tick(2 * time.Second)
+	assert.Equal(t, "Work 1/3 1", m.PlainView())
+	assert.InDelta(t, 2.0/7, m.sequenceProgress(), 0.001)
+
+	tick(1500 * time.Millisecond)
+	assert.False(t, m.done, "The sequence should carry on after the first segment")
+	assert.Equal(t, "Break 2/3 2", m.PlainView(), "The next segment should start when the first ended")
+	assert.Equal(t, 1, m.rung, "A transition should ring the bell")
+	assert.Equal(t, lipgloss.Color("#ff79c6"), m.spinnerStyle.GetForeground(), "The segment's theme should apply")
+
+	m, _ = pressKey(m, " ")
+	clock.Advance(time.Minute)
+	assert.Equal(t, "Break 2/3 (paused) 2", m.PlainView())
+	m, _ = pressKey(m, " ")
+
+	tick(500 * time.Millisecond)
+	assert.Equal(t, "Break 2/3 1", m.PlainView(), "Time spent paused should not count")
+
+	// Suspended past the rest of the sequence
+	assert.NotNil(t, tick(time.Hour))
+	assert.True(t, m.done, "The sequence should finish after the last segment")
+	assert.Equal(t, Completed, m.result().Outcome)
+	assert.Equal(t, 2, m.rung)
+
+	var segments []Event
+	for _, e := range events {
+		if e.Type == EventSegment {
+			segments = append(segments, e)
+		}
+	}
+	require.Len(t, segments, 2)
+	assert.Equal(t, "Break", segments[0].Segment)
+	assert.Equal(t, 2, segments[0].SegmentNumber)
+	assert.Equal(t, 3, segments[1].SegmentNumber)
+	assert.Equal(t, EventDone, events[len(events)-1].Type)
+}
+
+func TestRunSequenceRingsBell(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	var out bytes.Buffer
+	cfg := Config{
+		SpinnerType:  "none",
+		TimeInterval: time.Second,
+		Decrement:    1,
+		Clock:        clock,
+		Output:       &out,
+		Input:        strings.NewReader(""),
+		Sequence:     []Segment{{Label: "Work", Length: 1}, {Label: "Break", Length: 1}},
+	}
+
+	go func() {
+		for range 2 {
+			clock.BlockUntil(1)
+			clock.Advance(time.Second)
+		}
+	}()
+	result, err := Run(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, Completed, result.Outcome)
+	assert.Equal(t, 1, strings.Count(out.String(), "\a"), "The bell should ring through the display between segments")
+}
+
+func TestModelSequenceRestart(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	m := NewModel(Config{
+		SpinnerType:  "none",
+		TimeInterval: time.Second,
+		Decrement:    1,
+		Clock:        clock,
+		Output:       &bytes.Buffer{},
+		Sequence:     []Segment{{Label: "Work", Length: 2}, {Label: "Break", Length: 2}},
+	})
+	clock.Advance(3 * time.Second)
+	updated, _ := m.Update(tickMsg{tag: m.tickTag})
+	m = updated.(Model)
+	require.Equal(t, "Break 2/2 1", m.PlainView())
+
+	m, _ = pressKey(m, "r")
+	assert.Equal(t, "Work 1/2 2", m.PlainView(), "Restarting should go back to the first segment")
+}
+
+func TestModelAgenda(t *testing.T) {
+	now := time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(now)
+	var events []Event
+	m := NewModel(Config{
+		SpinnerType:  "none",
+		Title:        "Track A",
+		TimeInterval: time.Second,
+		Decrement:    1,
+		Format:       FormatClock,
+		Clock:        clock,
+		Output:       &bytes.Buffer{},
+		Agenda:       true,
+		OnEvent:      func(e Event) { events = append(events, e) },
+		Sequence: []Segment{
+			{Label: "Keynote", Length: 2700},
+			{Label: "Coffee", Deadline: now.Add(time.Hour)},
+			{Length: 600},
+		},
+	})
+	assert.Equal(t, "Now: Keynote — Next: Coffee 00:45:00", m.PlainView())
+
+	m, _ = pressKey(m, "p")
+	assert.Equal(t, 0, m.segment, "Moving back from the first segment should restart it")
+
+	clock.Advance(10 * time.Minute)
+	m, cmd := pressKey(m, "n")
+	assert.NotNil(t, cmd, "Moving on should keep the ticks going")
+	assert.Equal(t, "Now: Coffee — Next: Track A 00:50:00", m.PlainView(), "A segment with a deadline should count down to it")
+	assert.Equal(t, EventSegment, events[len(events)-1].Type)
+	assert.Equal(t, "Coffee", events[len(events)-1].Segment)
+
+	m, _ = pressKey(m, "n")
+	assert.Equal(t, "Now: Track A 00:10:00", m.PlainView(), "The last segment should have nothing next")
+	m, _ = pressKey(m, "n")
+	assert.Equal(t, 2, m.segment, "Moving on from the last segment should do nothing")
+
+	m, _ = pressKey(m, "p")
+	assert.Equal(t, "Now: Coffee — Next: Track A 00:50:00", m.PlainView())
+
+	m = NewModel(Config{
+		SpinnerType:  "none",
+		TimeInterval: time.Second,
+		Decrement:    1,
+		Format:       FormatClock,
+		Clock:        clock,
+		Agenda:       true,
+		AgendaTitle:  "Main hall",
+		Sequence:     []Segment{{Label: "Keynote", Length: 60}, {Label: "Lunch", Length: 60}},
+	})
+	assert.Equal(t, "Main hall — Now: Keynote — Next: Lunch 00:01:00", m.PlainView(), "The agenda's title should lead")
+}
+
+func TestSegmentConfigThresholds(t *testing.T) {
+	now := time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC)
+	tenth := func(length int) (int, []Stage) {
+		return length / 10, []Stage{{Threshold: length / 2}}
+	}
+	cfg := Config{
+		TimeInterval: time.Second,
+		Sequence: []Segment{
+			{Length: 600, FinalPhase: 60},
+			{Deadline: now.Add(time.Hour), Length: 3600, FinalPhase: 360, Thresholds: tenth},
+		},
+	}
+
+	got := cfg.segmentConfig(1, now.Add(30*time.Minute))
+	assert.Equal(t, 1800, got.Start)
+	assert.Equal(t, 180, got.FinalPhase, "Thresholds should be of the time left when the segment begins")
+	assert.Equal(t, []Stage{{Threshold: 900}}, got.Stages)
+
+	got = cfg.segmentConfig(0, now)
+	assert.Equal(t, 60, got.FinalPhase, "A segment without a deadline should keep its own")
+}
+
+func TestSegmentKeysOnlyInSequence(t *testing.T) {
+	m := NewModel(Config{Start: 10, End: 0, TimeInterval: time.Second, Decrement: 1})
+	assert.False(t, m.keys.Next.Enabled())
+	m, cmd := pressKey(m, "n")
+	assert.Nil(t, cmd)
+	assert.Equal(t, 10, m.current)
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package countdown
+
+import (
+	"os"
+	"syscall"
+)
+
+// shutdownSignals end the countdown according to Config.OnSignal.
+var shutdownSignals = []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP, syscall.SIGQUIT}
+
+// pauseSignals pause or resume the countdown.
+var pauseSignals = []os.Signal{syscall.SIGUSR1}
+
+// restartSignals restart the countdown from the beginning.
+var restartSignals = []os.Signal{syscall.SIGUSR2}
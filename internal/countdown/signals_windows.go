@@ -0,0 +1,17 @@
+//go:build windows
+
+package countdown
+
+import (
+	"os"
+	"syscall"
+)
+
+// shutdownSignals end the countdown according to Config.OnSignal.
+var shutdownSignals = []os.Signal{syscall.SIGTERM, os.Interrupt}
+
+// pauseSignals pause or resume the countdown. Windows has no user signals.
+var pauseSignals []os.Signal
+
+// restartSignals restart the countdown from the beginning.
+var restartSignals []os.Signal
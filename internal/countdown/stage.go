@@ -0,0 +1,135 @@
+package countdown
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Stage changes how the countdown looks once the count reaches its
+// threshold, such as turning yellow at 20% remaining and red at 10%.
+type Stage struct {
+	// Threshold is the count at which the stage begins, in the same units as
+	// Config.Start.
+	Threshold int
+	// Overtime makes the stage apply in overtime instead of from Threshold.
+	Overtime bool
+	// Foreground and Background color the count. When both are empty the
+	// theme's final phase style is used.
+	Foreground string
+	Background string
+	// Blink flashes the stage's style on and off with each step.
+	Blink bool
+	// Spinner replaces the spinner type, when set.
+	Spinner string
+	// Title replaces the title, when set.
+	Title string
+}
+
+// stageView is a stage along with the style for its count.
+type stageView struct {
+	Stage
+	style lipgloss.Style
+}
+
+// newStages returns the stages in the order the count reaches them, each with
+// its count style. Without any thresholds the final phase is the only one,
+// blinking in the final style as it always has. With Config.Overtime there is
+// always an overtime stage, in the overtime style unless one is given.
+// Counting endlessly, or blending the count's color, there is no final phase.
+func newStages(cfg Config, countStyle, finalStyle, overtimeStyle lipgloss.Style) []stageView {
+	var stages []stageView
+	if !cfg.Endless && (cfg.ColorFrom == "" || cfg.ColorTo == "") && !slices.ContainsFunc(cfg.Stages, func(s Stage) bool { return !s.Overtime }) {
+		stages = append(stages, stageView{
+			Stage: Stage{Threshold: cfg.FinalPhase, Blink: true},
+			style: finalStyle,
+		})
+	}
+	for _, s := range cfg.Stages {
+		style := finalStyle
+		if s.Foreground != "" || s.Background != "" {
+			style = countStyle.Bold(true)
+			if s.Foreground != "" {
+				style = style.Foreground(parseColor(s.Foreground))
+			}
+			if s.Background != "" {
+				style = style.Background(parseColor(s.Background))
+			}
+		}
+		stages = append(stages, stageView{Stage: s, style: style})
+	}
+	if cfg.Overtime && !slices.ContainsFunc(stages, func(s stageView) bool { return s.Overtime }) {
+		stages = append(stages, stageView{Stage: Stage{Overtime: true}, style: overtimeStyle})
+	}
+
+	countingDown := cfg.Start > cfg.End
+	slices.SortStableFunc(stages, func(a, b stageView) int {
+		switch {
+		case a.Overtime || b.Overtime:
+			// Overtime comes after every threshold
+			return cmp.Compare(boolInt(a.Overtime), boolInt(b.Overtime))
+		case countingDown:
+			return b.Threshold - a.Threshold
+		default:
+			return a.Threshold - b.Threshold
+		}
+	})
+	return stages
+}
+
+// boolInt returns 1 for true and 0 for false.
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// reached reports whether the count has reached threshold.
+func (m Model) reached(threshold int) bool {
+	if m.config.Start > m.config.End {
+		// Counting down
+		return m.current <= threshold
+	}
+	// Counting up
+	return m.current >= threshold
+}
+
+// currentStage returns the index of the furthest stage the count has
+// reached, or -1 before the first. In overtime it is the overtime stage.
+func (m Model) currentStage() int {
+	current := -1
+	for i, s := range m.stages {
+		switch {
+		case s.Overtime:
+			if m.overtime {
+				return i
+			}
+		case m.reached(s.Threshold):
+			current = i
+		}
+	}
+	return current
+}
+
+// stageStyle returns the style for the count in the current stage, and
+// whether there is one showing.
+func (m Model) stageStyle() (lipgloss.Style, bool) {
+	if m.stage < 0 {
+		return lipgloss.Style{}, false
+	}
+	s := m.stages[m.stage]
+	if s.Blink && !m.blinkOn() {
+		return lipgloss.Style{}, false
+	}
+	return s.style, true
+}
+
+// spinnerType returns the name of the spinner for the current stage.
+func (m Model) spinnerType() string {
+	if m.stage >= 0 && m.stages[m.stage].Spinner != "" {
+		return m.stages[m.stage].Spinner
+	}
+	return m.config.SpinnerType
+}
@@ -0,0 +1,101 @@
+package countdown
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStages(t *testing.T) {
+	countStyle := lipgloss.NewStyle()
+	finalStyle := lipgloss.NewStyle().Reverse(true)
+	overtimeStyle := lipgloss.NewStyle().Underline(true)
+
+	stages := newStages(Config{Start: 100, End: 0, FinalPhase: 5}, countStyle, finalStyle, overtimeStyle)
+	require.Len(t, stages, 1, "The final phase should be the only stage without any")
+	assert.Equal(t, 5, stages[0].Threshold)
+	assert.True(t, stages[0].Blink)
+	assert.True(t, stages[0].style.GetReverse())
+
+	stages = newStages(Config{
+		Start: 100,
+		End:   0,
+		Stages: []Stage{
+			{Threshold: 10, Foreground: "red"},
+			{Threshold: 50, Foreground: "green"},
+			{Threshold: 20, Blink: true},
+		},
+	}, countStyle, finalStyle, overtimeStyle)
+	require.Len(t, stages, 3)
+	assert.Equal(t, []int{50, 20, 10}, []int{stages[0].Threshold, stages[1].Threshold, stages[2].Threshold},
+		"Stages should be in the order the count reaches them")
+	assert.Equal(t, lipgloss.Color("2"), stages[0].style.GetForeground())
+	assert.True(t, stages[1].style.GetReverse(), "A stage without colors should use the final style")
+
+	stages = newStages(Config{
+		Start:  0,
+		End:    100,
+		Stages: []Stage{{Threshold: 90}, {Threshold: 50}},
+	}, countStyle, finalStyle, overtimeStyle)
+	assert.Equal(t, []int{50, 90}, []int{stages[0].Threshold, stages[1].Threshold}, "Counting up should reach lower thresholds first")
+}
+
+func TestModelStages(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC))
+	m := NewModel(Config{
+		SpinnerType:  "dot",
+		Title:        "Talk ends in",
+		Start:        10,
+		End:          0,
+		TimeInterval: time.Second,
+		Decrement:    1,
+		FinalPhase:   0,
+		Clock:        clock,
+		Stages: []Stage{
+			{Threshold: 6, Foreground: "yellow"},
+			{Threshold: 3, Foreground: "red", Blink: true, Spinner: "bomb", Title: "Wrap up"},
+		},
+	})
+	assert.Equal(t, -1, m.currentStage())
+	_, shown := m.stageStyle()
+	assert.False(t, shown)
+
+	tick := func() tea.Cmd {
+		clock.Advance(time.Second)
+		updated, cmd := m.Update(tickMsg{tag: m.tickTag})
+		m = updated.(Model)
+		return cmd
+	}
+
+	for range 4 {
+		tick()
+	}
+	assert.Equal(t, 6, m.current)
+	style, shown := m.stageStyle()
+	require.True(t, shown)
+	assert.Equal(t, lipgloss.Color("3"), style.GetForeground())
+	assert.Equal(t, "Talk ends in", m.titleText())
+
+	spinnerID := m.spinner.ID()
+	for range 2 {
+		tick()
+	}
+	cmd := tick()
+	assert.Equal(t, 3, m.current)
+	assert.Equal(t, "Wrap up", m.titleText())
+	assert.NotEqual(t, spinnerID, m.spinner.ID(), "The stage's spinner should replace the old one")
+	assert.Equal(t, GetSpinner("bomb").Frames, m.spinner.Spinner.Frames)
+	assert.NotNil(t, cmd)
+
+	style, shown = m.stageStyle()
+	require.True(t, shown, "Odd counts should show the blinking style")
+	assert.Equal(t, lipgloss.Color("1"), style.GetForeground())
+	tick()
+	_, shown = m.stageStyle()
+	assert.False(t, shown, "Even counts should hide the blinking style")
+	assert.Equal(t, "Wrap up 2", m.PlainView())
+}
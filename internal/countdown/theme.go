@@ -0,0 +1,151 @@
+package countdown
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Palette is the set of colors a theme uses on one kind of terminal
+// background. Each color is an ANSI number or hex code, or empty to leave the
+// terminal's own color.
+type Palette struct {
+	Spinner string `toml:"spinner" yaml:"spinner"`
+	Title   string `toml:"title" yaml:"title"`
+	Count   string `toml:"count" yaml:"count"`
+	// FinalForeground and FinalBackground color the count in the final
+	// phase. The foreground defaults to black or white, whichever stands out
+	// against the background. When both are empty the count is shown in
+	// reverse video.
+	FinalForeground string `toml:"final-foreground" yaml:"final-foreground"`
+	FinalBackground string `toml:"final-background" yaml:"final-background"`
+	Background      string `toml:"background" yaml:"background"`
+	// Overtime colors the count once it passes the end with Config.Overtime.
+	// When empty the count is underlined instead.
+	Overtime string `toml:"overtime" yaml:"overtime"`
+}
+
+// Theme is a named set of colors, with variants for light and dark terminal
+// backgrounds. The variant is picked by detecting the terminal's background.
+type Theme struct {
+	Name  string
+	Light Palette
+	Dark  Palette
+}
+
+var (
+	solarizedLight = Palette{
+		Spinner:         "#d33682",
+		Title:           "#586e75",
+		Count:           "#268bd2",
+		FinalForeground: "#fdf6e3",
+		FinalBackground: "#dc322f",
+		Background:      "#fdf6e3",
+		Overtime:        "#dc322f",
+	}
+	solarizedDark = Palette{
+		Spinner:         "#d33682",
+		Title:           "#93a1a1",
+		Count:           "#268bd2",
+		FinalForeground: "#002b36",
+		FinalBackground: "#cb4b16",
+		Background:      "#002b36",
+		Overtime:        "#dc322f",
+	}
+	dracula = Palette{
+		Spinner:         "#ff79c6",
+		Title:           "#f8f8f2",
+		Count:           "#bd93f9",
+		FinalForeground: "#282a36",
+		FinalBackground: "#ff5555",
+		Background:      "#282a36",
+		Overtime:        "#ff5555",
+	}
+	defaultPalette = Palette{
+		Spinner:         "212",
+		FinalBackground: "212",
+		Overtime:        "196",
+	}
+)
+
+// themes are the built-in themes, by name.
+var themes = map[string]Theme{
+	"default":         {Name: "default", Light: defaultPalette, Dark: defaultPalette},
+	"dracula":         {Name: "dracula", Light: dracula, Dark: dracula},
+	"solarized":       {Name: "solarized", Light: solarizedLight, Dark: solarizedDark},
+	"solarized-light": {Name: "solarized-light", Light: solarizedLight, Dark: solarizedLight},
+	"solarized-dark":  {Name: "solarized-dark", Light: solarizedDark, Dark: solarizedDark},
+	"high-contrast": {
+		Name: "high-contrast",
+		Light: Palette{
+			Spinner: "0", Title: "0", Count: "0",
+			FinalForeground: "0", FinalBackground: "11",
+			Background: "15", Overtime: "1",
+		},
+		Dark: Palette{
+			Spinner: "15", Title: "15", Count: "15",
+			FinalForeground: "0", FinalBackground: "11",
+			Background: "0", Overtime: "9",
+		},
+	},
+	"monochrome": {Name: "monochrome"},
+}
+
+// LookupTheme returns the built-in theme with the given name.
+func LookupTheme(name string) (Theme, bool) {
+	t, ok := themes[name]
+	return t, ok
+}
+
+// ThemeNames returns the names of the built-in themes in order.
+func ThemeNames() []string {
+	return slices.Sorted(maps.Keys(themes))
+}
+
+// color returns the theme's color for one part, taken from each variant by
+// part. An override, such as a color given by flag, replaces both variants.
+func (t Theme) color(part func(Palette) string, override string) lipgloss.TerminalColor {
+	if override != "" {
+		return parseColor(override)
+	}
+	light, dark := part(t.Light), part(t.Dark)
+	if light == dark {
+		return parseColor(light)
+	}
+	return lipgloss.AdaptiveColor{Light: light, Dark: dark}
+}
+
+// finalStyle returns the style for the count in the final phase. A foreground
+// color given by flag is swapped to the background, as before themes.
+func (t Theme) finalStyle(override string) lipgloss.Style {
+	style := lipgloss.NewStyle().Bold(true)
+	if override != "" {
+		return style.Background(parseColor(override)).Foreground(parseColor(highContrast(override)))
+	}
+
+	plain := func(p Palette) bool { return p.FinalForeground == "" && p.FinalBackground == "" }
+	if plain(t.Light) && plain(t.Dark) {
+		return style.Reverse(true)
+	}
+
+	foreground := func(p Palette) string {
+		if p.FinalForeground != "" {
+			return p.FinalForeground
+		}
+		return highContrast(p.FinalBackground)
+	}
+	return style.
+		Background(t.color(func(p Palette) string { return p.FinalBackground }, "")).
+		Foreground(t.color(foreground, ""))
+}
+
+// overtimeStyle returns the style for the count in overtime, based on the
+// count style.
+func (t Theme) overtimeStyle(countStyle lipgloss.Style) lipgloss.Style {
+	style := countStyle.Bold(true)
+	if t.Light.Overtime == "" && t.Dark.Overtime == "" {
+		return style.Underline(true)
+	}
+	return style.Foreground(t.color(func(p Palette) string { return p.Overtime }, ""))
+}
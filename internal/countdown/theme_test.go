@@ -0,0 +1,76 @@
+package countdown
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupTheme(t *testing.T) {
+	for _, name := range []string{"default", "dracula", "solarized-light", "high-contrast", "monochrome"} {
+		theme, ok := LookupTheme(name)
+		require.True(t, ok, "theme %s should be built in", name)
+		assert.Equal(t, name, theme.Name)
+	}
+
+	_, ok := LookupTheme("nonexistent")
+	assert.False(t, ok)
+	assert.Contains(t, ThemeNames(), "solarized")
+}
+
+func TestThemeColor(t *testing.T) {
+	solarized, _ := LookupTheme("solarized")
+	dracula, _ := LookupTheme("dracula")
+	spinner := func(p Palette) string { return p.Spinner }
+	title := func(p Palette) string { return p.Title }
+
+	tests := []struct {
+		name     string
+		theme    Theme
+		part     func(Palette) string
+		override string
+		want     lipgloss.TerminalColor
+	}{
+		{"same in both variants", dracula, spinner, "", lipgloss.Color("#ff79c6")},
+		{"differs by background", solarized, title, "", lipgloss.AdaptiveColor{Light: "#586e75", Dark: "#93a1a1"}},
+		{"override replaces both", solarized, title, "39", lipgloss.Color("39")},
+		{"unset", Theme{}, spinner, "", lipgloss.NoColor{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.theme.color(tt.part, tt.override))
+		})
+	}
+}
+
+func TestThemeFinalStyle(t *testing.T) {
+	def, _ := LookupTheme("default")
+	style := def.finalStyle("")
+	assert.Equal(t, lipgloss.Color("212"), style.GetBackground())
+	assert.Equal(t, lipgloss.Color("0"), style.GetForeground(), "Foreground should stand out from the background")
+	assert.True(t, style.GetBold())
+
+	style = def.finalStyle("#000080")
+	assert.Equal(t, lipgloss.Color("#000080"), style.GetBackground(), "A color given by flag should be swapped to the background")
+	assert.Equal(t, lipgloss.Color("15"), style.GetForeground())
+
+	mono, _ := LookupTheme("monochrome")
+	style = mono.finalStyle("")
+	assert.True(t, style.GetReverse(), "A theme without final colors should use reverse video")
+	assert.Equal(t, lipgloss.NoColor{}, style.GetBackground())
+}
+
+func TestNewModelWithTheme(t *testing.T) {
+	dracula, _ := LookupTheme("dracula")
+
+	m := NewModel(Config{Theme: dracula, TitleForeground: "39"})
+	assert.Equal(t, lipgloss.Color("#ff79c6"), m.spinnerStyle.GetForeground())
+	assert.Equal(t, lipgloss.Color("39"), m.titleStyle.GetForeground(), "Flags should override the theme")
+	assert.Equal(t, lipgloss.Color("#282a36"), m.containerStyle.GetBackground())
+
+	m = NewModel(Config{})
+	assert.Equal(t, lipgloss.Color("212"), m.spinnerStyle.GetForeground(), "The default theme should be used without one")
+}
@@ -0,0 +1,169 @@
+package countdown
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// letterPixels are bitmaps of the letters and punctuation of the title font,
+// five pixels high, which outline draws in the style of bigDigits. Lowercase
+// letters are a row shorter than capitals. No two pixels meet only at a
+// corner, which the outline can't draw.
+var letterPixels = map[rune][]string{
+	'A': {"###", "# #", "###", "# #", "# #"},
+	'B': {"### ", "# ##", "### ", "# ##", "### "},
+	'C': {"###", "#  ", "#  ", "#  ", "###"},
+	'D': {"### ", "# ##", "# ##", "# ##", "### "},
+	'E': {"###", "#  ", "###", "#  ", "###"},
+	'F': {"###", "#  ", "###", "#  ", "#  "},
+	'G': {"####", "#   ", "# ##", "#  #", "####"},
+	'H': {"# #", "# #", "###", "# #", "# #"},
+	'I': {"###", " # ", " # ", " # ", "###"},
+	'J': {"  #", "  #", "  #", "# #", "###"},
+	'K': {"#  #", "# ##", "### ", "# ##", "#  #"},
+	'L': {"#  ", "#  ", "#  ", "#  ", "###"},
+	'M': {"#####", "# # #", "# # #", "#   #", "#   #"},
+	'N': {"#  #", "## #", "####", "# ##", "#  #"},
+	'O': {"###", "# #", "# #", "# #", "###"},
+	'P': {"###", "# #", "###", "#  ", "#  "},
+	'Q': {"### ", "# # ", "# # ", "### ", "  ##"},
+	'R': {"### ", "# # ", "### ", "# ##", "#  #"},
+	'S': {"###", "#  ", "###", "  #", "###"},
+	'T': {"###", " # ", " # ", " # ", " # "},
+	'U': {"# #", "# #", "# #", "# #", "###"},
+	'V': {"# #", "# #", "# #", "###", " # "},
+	'W': {"#   #", "#   #", "# # #", "# # #", "#####"},
+	'X': {"# #", "###", " # ", "###", "# #"},
+	'Y': {"# #", "# #", "###", " # ", " # "},
+	'Z': {"####", "  ##", " ## ", "##  ", "####"},
+
+	'a': {"   ", "###", "# #", "###", "# #"},
+	'b': {"   ", "## ", "###", "# #", "###"},
+	'c': {"   ", "###", "#  ", "#  ", "###"},
+	'd': {"   ", "  #", "###", "# #", "###"},
+	'e': {"    ", "####", "# ##", "#   ", "####"},
+	'f': {"   ", " ##", " # ", "###", " # "},
+	'g': {"   ", "###", "#  ", "# #", "###"},
+	'h': {"   ", "#  ", "###", "# #", "# #"},
+	'i': {"#", " ", "#", "#", "#"},
+	'j': {"  #", "   ", "  #", "# #", "###"},
+	'k': {"    ", "# ##", "### ", "# ##", "#  #"},
+	'l': {"  ", "# ", "# ", "# ", "##"},
+	'm': {"     ", "#####", "# # #", "# # #", "# # #"},
+	'n': {"   ", "###", "# #", "# #", "# #"},
+	'o': {"   ", "###", "# #", "# #", "###"},
+	'p': {"   ", "###", "# #", "###", "#  "},
+	'q': {"   ", "###", "# #", "###", "  #"},
+	'r': {"   ", "###", "#  ", "#  ", "#  "},
+	's': {"   ", "###", "## ", " ##", "###"},
+	't': {"   ", " # ", "###", " # ", " ##"},
+	'u': {"   ", "# #", "# #", "# #", "###"},
+	'v': {"   ", "# #", "# #", "###", " # "},
+	'w': {"     ", "# # #", "# # #", "# # #", "#####"},
+	'x': {"   ", "# #", "###", "###", "# #"},
+	'y': {"   ", "# #", "###", "  #", "###"},
+	'z': {"   ", "###", " ##", "## ", "###"},
+
+	' ':  {"  ", "  ", "  ", "  ", "  "},
+	'!':  {"#", "#", "#", " ", "#"},
+	'?':  {"###", "  #", " ##", "   ", " # "},
+	'.':  {" ", " ", " ", " ", "#"},
+	',':  {" ", " ", " ", "#", "#"},
+	':':  {" ", "#", " ", "#", " "},
+	';':  {" ", "#", " ", "#", "#"},
+	'\'': {"#", "#", " ", " ", " "},
+	'"':  {"# #", "# #", "   ", "   ", "   "},
+	'-':  {"   ", "   ", "###", "   ", "   "},
+	'+':  {"   ", " # ", "###", " # ", "   "},
+	'_':  {"   ", "   ", "   ", "   ", "###"},
+	'/':  {"  #", " ##", " # ", "## ", "#  "},
+	'(':  {"##", "# ", "# ", "# ", "##"},
+	')':  {"##", " #", " #", " #", "##"},
+}
+
+// corners are the box-drawing characters where the edges of an outline meet,
+// by whether there is an edge above, below, to the left and to the right.
+var corners = map[[4]bool]rune{
+	{true, true, false, false}:   '│',
+	{false, false, true, true}:   '─',
+	{false, true, false, true}:   '╭',
+	{false, true, true, false}:   '╮',
+	{true, false, false, true}:   '╰',
+	{true, false, true, false}:   '╯',
+	{true, true, true, true}:     '┼',
+	{false, false, false, false}: ' ',
+}
+
+// outline draws the edges of a bitmap of '#' pixels with rounded corners.
+// Each line is the edge between two rows of pixels, and each pixel is two
+// cells wide, so a glyph is one line taller than its bitmap.
+func outline(pixels []string) []string {
+	width := 0
+	for _, row := range pixels {
+		width = max(width, len(row))
+	}
+	filled := func(x, y int) bool {
+		return y >= 0 && y < len(pixels) && x >= 0 && x < len(pixels[y]) && pixels[y][x] == '#'
+	}
+
+	lines := make([]string, len(pixels)+1)
+	for y := range lines {
+		var b strings.Builder
+		for x := 0; x <= width; x++ {
+			tl, tr, bl, br := filled(x-1, y-1), filled(x, y-1), filled(x-1, y), filled(x, y)
+			b.WriteRune(corners[[4]bool{tl != tr, bl != br, tl != bl, tr != br}])
+			if x < width {
+				if tr != br {
+					b.WriteRune('─')
+				} else {
+					b.WriteRune(' ')
+				}
+			}
+		}
+		lines[y] = b.String()
+	}
+	return lines
+}
+
+// titleFont draws the title with BigTitle: letterPixels outlined, and the
+// digits of the default font.
+var titleFont = func() Font {
+	glyphs := maps.Clone(bigDigits)
+	for r, pixels := range letterPixels {
+		glyphs[r] = outline(pixels)
+	}
+	return Font{Name: "title", Height: 6, Glyphs: glyphs}
+}()
+
+// bigTitleLayout reports whether the title fits the terminal in big letters
+// with Config.BigTitle, above or beside the count, and whether there is room
+// to put it beside. A title with characters the font has no glyph for is
+// never big. Without a terminal size the title goes above the count.
+func (m Model) bigTitleLayout(countView string) (big, beside bool) {
+	text := m.titleText()
+	for _, r := range text {
+		if _, ok := titleFont.Glyphs[r]; !ok {
+			return false, false
+		}
+	}
+	if m.width == 0 {
+		return true, false
+	}
+
+	title := lipgloss.Width(m.spinner.View()) + 1 + titleFont.Width(text)
+	width := m.width - m.containerStyle.GetHorizontalFrameSize()
+	height := m.height - m.containerStyle.GetVerticalFrameSize()
+	if title > width {
+		return false, false
+	}
+	if title+len(titleGap)+lipgloss.Width(countView) <= width &&
+		(m.height == 0 || max(titleFont.Height, lipgloss.Height(countView)) <= height) {
+		return true, true
+	}
+	return m.height == 0 || titleFont.Height+lipgloss.Height(countView) <= height, false
+}
+
+// titleGap separates a big title from the count beside it.
+const titleGap = "  "
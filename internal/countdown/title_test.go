@@ -0,0 +1,63 @@
+package countdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutline(t *testing.T) {
+	assert.Equal(t, []string{
+		"╭─────╮",
+		"│ ╭─╮ │",
+		"│ ╰─╯ │",
+		"│ ╭─╮ │",
+		"│ │ │ │",
+		"╰─╯ ╰─╯",
+	}, outline(letterPixels['A']))
+	assert.Equal(t, []string{"╭─╮", "╰─╯"}, outline([]string{"#"}))
+	assert.Equal(t, []string{"   ", "   "}, outline([]string{" "}))
+}
+
+func TestTitleFont(t *testing.T) {
+	text := "ABCDEFGHIJKLMNOPQRSTUVWXYZ abcdefghijklmnopqrstuvwxyz 0123456789 !?.,:;'\"-+_/()"
+	for _, r := range text {
+		glyph, ok := titleFont.Glyphs[r]
+		if !assert.True(t, ok, "The title font should have a glyph for %q", r) {
+			continue
+		}
+		assert.Len(t, glyph, titleFont.Height)
+		for _, line := range glyph {
+			assert.Equal(t, titleFont.GlyphWidth(r), lipgloss.Width(line), "Every line of the glyph for %q should be the same width", r)
+			assert.NotContains(t, line, "┼", "Pixels of %q should not meet only at a corner", r)
+		}
+	}
+}
+
+func TestViewBigTitle(t *testing.T) {
+	cfg := Config{SpinnerType: "none", Title: "Talk", Start: 10, End: 0, Big: true, BigTitle: true}
+	title := titleFont.Render("Talk")
+	count := renderBigText("10")
+
+	lines := strings.Split(NewModel(cfg).View(), "\n")
+	require.Len(t, lines, 12, "The title should be above the count without a terminal size")
+	assert.Equal(t, " "+strings.Split(title, "\n")[0], lines[0])
+
+	lines = strings.Split(resize(NewModel(cfg), 80, 24).View(), "\n")
+	require.Len(t, lines, 6, "The title should be beside the count when there's room")
+	assert.Equal(t, " "+strings.Split(title, "\n")[5]+titleGap+strings.Split(count, "\n")[5], lines[5])
+
+	width := 1 + lipgloss.Width(title) + len(titleGap) + lipgloss.Width(count)
+	assert.Len(t, strings.Split(resize(NewModel(cfg), width-1, 24).View(), "\n"), 12, "The title should be above the count when there isn't room beside it")
+	assert.Len(t, strings.Split(resize(NewModel(cfg), width, 24).View(), "\n"), 6)
+
+	lines = strings.Split(resize(NewModel(cfg), width-1, 10).View(), "\n")
+	assert.Equal(t, " Talk", strings.TrimRight(lines[0], " "), "A title which fits neither beside nor above the count should be small")
+
+	cfg.Title = "Q&A"
+	lines = strings.Split(resize(NewModel(cfg), 80, 24).View(), "\n")
+	assert.Equal(t, " Q&A", strings.TrimRight(lines[0], " "), "A title with characters the font can't draw should be small")
+}
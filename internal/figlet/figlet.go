@@ -0,0 +1,414 @@
+// Package figlet loads FIGlet fonts (.flf files) and draws text with them.
+//
+// A font file starts with a header such as
+//
+//	flf2a$ 6 5 16 15 3 0 24463
+//
+// giving the hardblank character after "flf2a", the height of every glyph,
+// its baseline, the longest line, the old layout, the number of comment lines
+// and optionally the print direction and full layout. The glyphs for ASCII 32
+// to 126 follow in order, then those for ÄÖÜäöüß, and then any glyphs tagged
+// with their character code. Each line of a glyph ends with an endmark,
+// doubled on its last line.
+//
+// Glyphs are joined at full width, fitted together until they touch, or
+// smushed into one another by the font's smushing rules. Only left-to-right
+// printing and horizontal layout are supported.
+package figlet
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Layout is how glyphs are joined side by side.
+type Layout int
+
+const (
+	// FullWidth leaves every glyph at its full width.
+	FullWidth Layout = iota
+	// Fitted moves glyphs together until they touch.
+	Fitted
+	// Smushed moves glyphs one column further than Fitted, merging the
+	// characters which meet by the font's smushing rules.
+	Smushed
+)
+
+// Smushing rules, which can be combined. A Smushed font without any merges
+// any two characters, the later one winning, which is universal smushing.
+const (
+	// SmushEqual merges two of the same character into one.
+	SmushEqual = 1 << iota
+	// SmushUnderscore lets a border character such as | or / replace an
+	// underscore.
+	SmushUnderscore
+	// SmushHierarchy lets the later of the classes | /\ [] {} () <> replace
+	// an earlier one.
+	SmushHierarchy
+	// SmushOpposite merges opposite brackets, such as ][, into a |.
+	SmushOpposite
+	// SmushBigX merges /\ into |, \/ into Y and >< into X.
+	SmushBigX
+	// SmushHardblank merges two hardblanks into one.
+	SmushHardblank
+)
+
+// Font is a FIGlet font.
+type Font struct {
+	// Height is the number of lines in every glyph, and Baseline the line,
+	// counting from 1, that characters without descenders sit on.
+	Height   int
+	Baseline int
+	// Hardblank is drawn as a space, but is not moved into or smushed like
+	// one.
+	Hardblank rune
+	Layout    Layout
+	// Rules are the smushing rules of a Smushed font.
+	Rules int
+	// Glyphs holds the lines of each character's glyph, padded to the same
+	// width.
+	Glyphs map[rune][]string
+}
+
+// Error is a problem with a font file, at a line when known.
+type Error struct {
+	Path string
+	Line int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %v", e.Path, e.Err)
+	}
+	return fmt.Sprintf("%s:%d: %v", e.Path, e.Line, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// signature starts the header of every font file.
+const signature = "flf2a"
+
+// Old layout values, which fonts without a full layout use.
+const (
+	oldFullWidth = -1
+	oldFitted    = 0
+)
+
+// Full layout bits choosing fitting or smushing.
+const (
+	fullFitted  = 64
+	fullSmushed = 128
+)
+
+// required are the characters every font has glyphs for in order, without
+// tags: ASCII 32 to 126 and then seven German letters.
+var required = append([]rune(" !\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~"), []rune("ÄÖÜäöüß")...)
+
+// Load reads the font file at path.
+func Load(path string) (*Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, data)
+}
+
+// Parse reads a font from data, naming path in any errors. A file which
+// ends between glyphs is accepted, since many fonts leave out some of ASCII.
+func Parse(path string, data []byte) (*Font, error) {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &Error{Path: path, Err: err}
+	}
+	if len(lines) == 0 {
+		return nil, &Error{Path: path, Err: errors.New("empty font file")}
+	}
+
+	f, comments, err := parseHeader(lines[0])
+	if err != nil {
+		return nil, &Error{Path: path, Line: 1, Err: err}
+	}
+	f.Glyphs = map[rune][]string{}
+
+	// Line numbers count from 1, and the header and comments come first
+	next := 1 + comments
+	readGlyph := func(r rune) error {
+		if next+f.Height > len(lines) {
+			return &Error{Path: path, Line: len(lines), Err: fmt.Errorf("glyph for %q has %d of %d lines", r, len(lines)-next, f.Height)}
+		}
+		f.Glyphs[r] = glyph(lines[next : next+f.Height])
+		next += f.Height
+		return nil
+	}
+
+	for _, r := range required {
+		if next >= len(lines) {
+			return f, nil
+		}
+		if err := readGlyph(r); err != nil {
+			return nil, err
+		}
+	}
+	for next < len(lines) {
+		if strings.TrimSpace(lines[next]) == "" {
+			next++
+			continue
+		}
+		code, err := parseCode(lines[next])
+		if err != nil {
+			return nil, &Error{Path: path, Line: next + 1, Err: err}
+		}
+		next++
+		if err := readGlyph(code); err != nil {
+			return nil, err
+		}
+		if code < 0 {
+			// Negative codes are for translation tables, not characters
+			delete(f.Glyphs, code)
+		}
+	}
+	return f, nil
+}
+
+// parseHeader reads the header line, returning the font without its glyphs
+// and the number of comment lines which follow.
+func parseHeader(line string) (*Font, int, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], signature) {
+		return nil, 0, fmt.Errorf("not a FIGlet font (expected a header starting %q)", signature)
+	}
+	hardblank, size := utf8.DecodeRuneInString(fields[0][len(signature):])
+	if size == 0 {
+		return nil, 0, errors.New("header has no hardblank after " + signature)
+	}
+	if len(fields) < 6 {
+		return nil, 0, fmt.Errorf("header has %d of at least 6 fields", len(fields))
+	}
+
+	names := []string{"height", "baseline", "max length", "old layout", "comment lines", "print direction", "full layout"}
+	values := make([]int, min(len(fields)-1, len(names)))
+	for i := range values {
+		v, err := strconv.Atoi(fields[i+1])
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid %s in header: %s", names[i], fields[i+1])
+		}
+		values[i] = v
+	}
+	height, baseline, oldLayout, comments := values[0], values[1], values[3], values[4]
+	switch {
+	case height < 1:
+		return nil, 0, fmt.Errorf("invalid height in header: %d", height)
+	case comments < 0:
+		return nil, 0, fmt.Errorf("invalid comment lines in header: %d", comments)
+	}
+
+	f := &Font{Height: height, Baseline: min(max(baseline, 1), height), Hardblank: hardblank}
+	if len(values) > 6 {
+		full := values[6]
+		switch {
+		case full&fullSmushed != 0:
+			f.Layout, f.Rules = Smushed, full&63
+		case full&fullFitted != 0:
+			f.Layout = Fitted
+		}
+		return f, comments, nil
+	}
+	switch {
+	case oldLayout == oldFullWidth:
+	case oldLayout == oldFitted:
+		f.Layout = Fitted
+	case oldLayout > 0:
+		f.Layout, f.Rules = Smushed, oldLayout&63
+	default:
+		return nil, 0, fmt.Errorf("invalid old layout in header: %d", oldLayout)
+	}
+	return f, comments, nil
+}
+
+// parseCode reads the character code at the start of a code-tagged glyph,
+// which is decimal, octal with a leading 0 or hexadecimal with 0x.
+func parseCode(line string) (rune, error) {
+	field := strings.Fields(line)[0]
+	code, err := strconv.ParseInt(field, 0, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid character code: %s", field)
+	}
+	return rune(code), nil
+}
+
+// glyph strips the endmarks from the lines of a glyph, along with any
+// whitespace after them, and pads the lines to the same width.
+func glyph(lines []string) []string {
+	rows := make([]string, len(lines))
+	width := 0
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if endmark, size := utf8.DecodeLastRuneInString(line); size > 0 {
+			line = strings.TrimRight(line, string(endmark))
+		}
+		rows[i] = line
+		width = max(width, utf8.RuneCountInString(line))
+	}
+	for i, row := range rows {
+		rows[i] = row + strings.Repeat(" ", width-utf8.RuneCountInString(row))
+	}
+	return rows
+}
+
+// Render draws text in the font, joining the glyphs by its layout.
+// Characters without a glyph are skipped, and hardblanks are drawn as
+// spaces.
+func (f *Font) Render(text string) string {
+	rows := make([][]rune, f.Height)
+	prevWidth := 0
+	for _, r := range text {
+		lines, ok := f.Glyphs[r]
+		if !ok {
+			continue
+		}
+		glyph := make([][]rune, f.Height)
+		for i := range glyph {
+			glyph[i] = []rune(lines[i])
+		}
+		width := len(glyph[0])
+
+		amount := f.overlap(rows, glyph, prevWidth, width)
+		for i := range rows {
+			for k := range amount {
+				col := len(rows[i]) - amount + k
+				if c := f.smush(rows[i][col], glyph[i][k], prevWidth, width); c != 0 {
+					rows[i][col] = c
+				}
+			}
+			rows[i] = append(rows[i], glyph[i][amount:]...)
+		}
+		prevWidth = width
+	}
+
+	if len(rows[0]) == 0 {
+		return ""
+	}
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = strings.ReplaceAll(string(row), string(f.Hardblank), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// overlap returns how many columns a glyph of width can be moved into the
+// rows drawn so far: as far as every line allows, up to the glyph's width.
+func (f *Font) overlap(rows, glyph [][]rune, prevWidth, width int) int {
+	if f.Layout == FullWidth || len(rows[0]) == 0 {
+		return 0
+	}
+	amount := min(width, len(rows[0]))
+	for i, row := range rows {
+		line := glyph[i]
+		trailing := 0
+		for trailing < len(row) && row[len(row)-1-trailing] == ' ' {
+			trailing++
+		}
+		leading := 0
+		for leading < len(line) && line[leading] == ' ' {
+			leading++
+		}
+
+		n := trailing + leading
+		if trailing < len(row) && leading < len(line) && f.smush(row[len(row)-1-trailing], line[leading], prevWidth, width) != 0 {
+			n++
+		}
+		amount = min(amount, n)
+	}
+	return amount
+}
+
+// hierarchy are the classes of SmushHierarchy, from lowest to highest.
+var hierarchy = []string{"|", `/\`, "[]", "{}", "()", "<>"}
+
+// smush returns the character l and r merge into, or 0 if they can't. A
+// space merges with anything, and other characters only by the smushing
+// rules between glyphs at least two columns wide.
+func (f *Font) smush(l, r rune, prevWidth, width int) rune {
+	switch {
+	case l == ' ':
+		return r
+	case r == ' ':
+		return l
+	case f.Layout != Smushed || prevWidth < 2 || width < 2:
+		return 0
+	}
+
+	hb := f.Hardblank
+	if f.Rules == 0 {
+		if l == hb {
+			return r
+		}
+		if r == hb {
+			return l
+		}
+		return r
+	}
+	if f.Rules&SmushHardblank != 0 && l == hb && r == hb {
+		return l
+	}
+	if l == hb || r == hb {
+		return 0
+	}
+	if f.Rules&SmushEqual != 0 && l == r {
+		return l
+	}
+	if f.Rules&SmushUnderscore != 0 {
+		const borders = `|/\[]{}()<>`
+		if l == '_' && strings.ContainsRune(borders, r) {
+			return r
+		}
+		if r == '_' && strings.ContainsRune(borders, l) {
+			return l
+		}
+	}
+	if f.Rules&SmushHierarchy != 0 {
+		class := func(c rune) int {
+			for i, chars := range hierarchy {
+				if strings.ContainsRune(chars, c) {
+					return i
+				}
+			}
+			return -1
+		}
+		lc, rc := class(l), class(r)
+		if lc >= 0 && rc >= 0 && lc != rc {
+			if lc > rc {
+				return l
+			}
+			return r
+		}
+	}
+	if f.Rules&SmushOpposite != 0 && strings.Contains("[] ][ {} }{ () )(", string([]rune{l, r})) {
+		return '|'
+	}
+	if f.Rules&SmushBigX != 0 {
+		switch string([]rune{l, r}) {
+		case `/\`:
+			return '|'
+		case `\/`:
+			return 'Y'
+		case "><":
+			return 'X'
+		}
+	}
+	return 0
+}
@@ -0,0 +1,169 @@
+package figlet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// font returns the text of a font file three lines high with the header
+// fields after the height and the glyphs given, by character. The other
+// required characters are blank, and the rest are tagged with their codes.
+func font(header string, glyphs map[rune][]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "flf2a$ 3 %s\n", header)
+	writeGlyph := func(lines []string) {
+		for i, line := range lines {
+			b.WriteString(line + "@")
+			if i == len(lines)-1 {
+				b.WriteString("@")
+			}
+			b.WriteString("\n")
+		}
+	}
+	for _, r := range required {
+		lines, ok := glyphs[r]
+		if !ok {
+			lines = []string{"$", "$", "$"}
+		}
+		writeGlyph(lines)
+	}
+	for r, lines := range glyphs {
+		if !slices.Contains(required, r) {
+			fmt.Fprintf(&b, "%d  TAGGED\n", r)
+			writeGlyph(lines)
+		}
+	}
+	return b.String()
+}
+
+// glyphs are drawn so that each layout joins them differently.
+var glyphs = map[rune][]string{
+	'/':  {"  /", " / ", "/  "},
+	'\\': {`\  `, ` \ `, `  \`},
+	'|':  {"| ", "| ", "| "},
+	'_':  {"   ", "   ", "___"},
+	'1':  {" _ ", "/ |", "|_|"},
+	'€':  {" _", "|=", "|_"},
+}
+
+func TestParse(t *testing.T) {
+	f, err := Parse("test.flf", []byte(font("2 3 0 0", glyphs)))
+	require.NoError(t, err)
+	assert.Equal(t, 3, f.Height)
+	assert.Equal(t, 2, f.Baseline)
+	assert.Equal(t, '$', f.Hardblank)
+	assert.Equal(t, Fitted, f.Layout)
+	assert.Equal(t, []string{"  /", " / ", "/  "}, f.Glyphs['/'])
+	assert.Equal(t, []string{" _", "|=", "|_"}, f.Glyphs['€'], "Code-tagged glyphs should be read")
+	assert.Equal(t, []string{"$", "$", "$"}, f.Glyphs['ß'])
+
+	f, err = Parse("test.flf", []byte("flf2a$ 1 1 2 0 0\n$@@\n|@@\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"|"}, f.Glyphs['!'])
+	assert.NotContains(t, f.Glyphs, '"', "Glyphs should stop where the file does")
+}
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		layout Layout
+		rules  int
+	}{
+		{"old full width", "2 3 -1 0", FullWidth, 0},
+		{"old fitted", "2 3 0 0", Fitted, 0},
+		{"old smushed", "2 3 15 0", Smushed, 15},
+		{"full layout full width", "2 3 15 0 0 0", FullWidth, 0},
+		{"full layout fitted", "2 3 -1 0 0 64", Fitted, 0},
+		{"full layout smushed", "2 3 -1 0 0 129", Smushed, SmushEqual},
+		{"universal", "2 3 -1 0 0 128", Smushed, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Parse("test.flf", []byte(font(tt.header, nil)))
+			require.NoError(t, err)
+			assert.Equal(t, tt.layout, f.Layout)
+			assert.Equal(t, tt.rules, f.Rules)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", "test.flf: empty font file"},
+		{"not a font", "hello\n", "test.flf:1: not a FIGlet font"},
+		{"no hardblank", "flf2a 3 2 3 0 0\n", "test.flf:1: header has no hardblank"},
+		{"short header", "flf2a$ 3 2\n", "test.flf:1: header has 3 of at least 6 fields"},
+		{"bad height", "flf2a$ x 2 3 0 0\n", "test.flf:1: invalid height in header: x"},
+		{"zero height", "flf2a$ 0 2 3 0 0\n", "test.flf:1: invalid height in header: 0"},
+		{"bad layout", "flf2a$ 3 2 3 -2 0\n", "test.flf:1: invalid old layout in header: -2"},
+		{"short glyph", "flf2a$ 3 2 3 0 1\ncomment\n $@\n $@\n", "test.flf:4: glyph for ' ' has 2 of 3 lines"},
+		{"bad code", font("2 3 0 0", map[rune][]string{'!': {"|", "|", "."}}) + "oops\n", "test.flf:308: invalid character code: oops"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("test.flf", []byte(tt.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+
+			var ferr *Error
+			assert.True(t, errors.As(err, &ferr))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		text   string
+		want   []string
+	}{
+		{"full width", "2 3 -1 0", "|_", []string{"|    ", "|    ", "| ___"}},
+		{"fitted", "2 3 0 0", "|_", []string{"|   ", "|   ", "|___"}},
+		{"fitted touching", "2 3 0 0", `/\`, []string{`  /\  `, ` /  \ `, `/    \`}},
+		{"fitted keeps hardblanks", "2 3 0 0", "| |", []string{"| | ", "| | ", "| | "}},
+		{"universal smushing", "2 3 -1 0 0 128", `/\`, []string{`  \  `, ` / \ `, `/   \`}},
+		{"big x", "2 3 -1 0 0 144", `/\`, []string{`  |  `, ` / \ `, `/   \`}},
+		{"equal", "2 3 1 0", "||", []string{"| ", "| ", "| "}},
+		{"equal only where every line allows", "2 3 1 0", "11", []string{" _  _ ", "/ |/ |", "|_||_|"}},
+		{"underscore", "2 3 2 0", "_|", []string{"  | ", "  | ", "__| "}},
+		{"no rule fits", "2 3 1 0", "|_", []string{"|   ", "|   ", "|___"}},
+		{"missing glyphs skipped", "2 3 -1 0", "1é€", []string{" _  _", "/ ||=", "|_||_"}},
+		{"nothing drawn", "2 3 -1 0", "é", []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Parse("test.flf", []byte(font(tt.header, glyphs)))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, strings.Split(f.Render(tt.text), "\n"))
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.flf")
+	require.NoError(t, os.WriteFile(path, []byte(font("2 3 0 0", glyphs)), 0o644))
+
+	f, err := Load(path)
+	require.NoError(t, err)
+	assert.Contains(t, f.Glyphs, '1')
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.flf"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,249 @@
+// Package playlist loads agendas of items to count down in turn, such as the
+// talks in a conference track, from YAML or JSON files.
+//
+// An agenda is either a list of items or a mapping with a title and a list of
+// items:
+//
+//	title: Track A
+//	items:
+//	  - title: Opening keynote
+//	    duration: 45m
+//	    stages: ["20%:yellow", "10%:red,blink"]
+//	  - title: Lunch
+//	    until: "13:30"
+//
+// Each item has a title and either a duration or an until time, which are
+// checked by Load only for their shape. Problems found later with their
+// values can still be reported against the file with Item.Errorf.
+package playlist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Playlist is an agenda loaded from a file.
+type Playlist struct {
+	// Title names the agenda as a whole, when given.
+	Title string
+	Items []Item
+}
+
+// Item is one entry in an agenda.
+type Item struct {
+	Title string
+	// Duration is how long the item lasts, such as "45m" or "01:30:00".
+	// Exactly one of Duration and Until is set.
+	Duration string
+	// Until is the time the item ends, such as "13:30".
+	Until string
+	// Stages are the item's stage thresholds, written as for --stage.
+	Stages []string
+	// Theme names the item's color theme, when given.
+	Theme string
+
+	path string
+	line int
+	// lines holds the line of each field given, by key.
+	lines map[string]int
+}
+
+// Error is a problem with an agenda file, at a line when known.
+type Error struct {
+	Path string
+	Line int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %v", e.Path, e.Err)
+	}
+	return fmt.Sprintf("%s:%d: %v", e.Path, e.Line, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// itemKeys are the keys an item can have.
+var itemKeys = []string{"title", "duration", "until", "stages", "theme"}
+
+// Load reads the agenda file at path. JSON is read as YAML, of which it is a
+// part, and both give errors with line numbers.
+func Load(path string) (*Playlist, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, data)
+}
+
+// Parse reads an agenda from data, naming path in any errors.
+func Parse(path string, data []byte) (*Playlist, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, syntaxError(path, data, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, &Error{Path: path, Err: errors.New("no items")}
+	}
+
+	p := &Playlist{}
+	root, items := doc.Content[0], doc.Content[0]
+	if root.Kind == yaml.MappingNode {
+		items = nil
+		for i := 0; i < len(root.Content); i += 2 {
+			key, value := root.Content[i], root.Content[i+1]
+			switch key.Value {
+			case "title":
+				if value.Kind != yaml.ScalarNode {
+					return nil, errorf(path, value, "title must be text")
+				}
+				p.Title = value.Value
+			case "items":
+				items = value
+			default:
+				return nil, errorf(path, key, "unknown key %q (expected title or items)", key.Value)
+			}
+		}
+		if items == nil {
+			return nil, errorf(path, root, "no items")
+		}
+	}
+	if items.Kind != yaml.SequenceNode {
+		return nil, errorf(path, items, "items must be a list")
+	}
+	if len(items.Content) == 0 {
+		return nil, errorf(path, items, "no items")
+	}
+
+	for _, node := range items.Content {
+		item, err := parseItem(path, node)
+		if err != nil {
+			return nil, err
+		}
+		p.Items = append(p.Items, item)
+	}
+	return p, nil
+}
+
+// parseItem reads one item, checking that it has the keys it needs.
+func parseItem(path string, node *yaml.Node) (Item, error) {
+	item := Item{path: path, line: node.Line, lines: map[string]int{}}
+	if node.Kind != yaml.MappingNode {
+		return item, errorf(path, node, "item must be a mapping with a title and a duration or until time")
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !slices.Contains(itemKeys, key.Value) {
+			return item, errorf(path, key, "unknown key %q in item", key.Value)
+		}
+		if _, ok := item.lines[key.Value]; ok {
+			return item, errorf(path, key, "%s given twice", key.Value)
+		}
+		item.lines[key.Value] = value.Line
+
+		if key.Value == "stages" {
+			stages, err := scalars(path, value)
+			if err != nil {
+				return item, err
+			}
+			item.Stages = stages
+			continue
+		}
+		if value.Kind != yaml.ScalarNode || value.Value == "" {
+			return item, errorf(path, value, "%s must not be empty", key.Value)
+		}
+		switch key.Value {
+		case "title":
+			item.Title = value.Value
+		case "duration":
+			item.Duration = value.Value
+		case "until":
+			item.Until = value.Value
+		case "theme":
+			item.Theme = value.Value
+		}
+	}
+
+	switch {
+	case item.Title == "":
+		return item, errorf(path, node, "item has no title")
+	case item.Duration == "" && item.Until == "":
+		return item, errorf(path, node, "item %q needs a duration or until time", item.Title)
+	case item.Duration != "" && item.Until != "":
+		return item, item.Errorf("until", "item %q has both a duration and an until time", item.Title)
+	}
+	return item, nil
+}
+
+// scalars reads a list of text values, or a single one as a list of one.
+func scalars(path string, node *yaml.Node) ([]string, error) {
+	if node.Kind == yaml.ScalarNode {
+		return []string{node.Value}, nil
+	}
+	if node.Kind != yaml.SequenceNode {
+		return nil, errorf(path, node, "stages must be a list")
+	}
+	values := make([]string, len(node.Content))
+	for i, n := range node.Content {
+		if n.Kind != yaml.ScalarNode {
+			return nil, errorf(path, n, "stage must be text such as \"10%%:red\"")
+		}
+		values[i] = n.Value
+	}
+	return values, nil
+}
+
+// yamlLine matches the line number at the start of a YAML syntax error.
+var yamlLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// syntaxError returns an Error for a YAML syntax error, moving its line
+// number to the Error. Within brackets, as all of JSON is, YAML gives the line
+// the brackets open on, so JSON is decoded again to find where the error is.
+func syntaxError(path string, data []byte, err error) error {
+	var jsonErr *json.SyntaxError
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') &&
+		errors.As(json.Unmarshal(data, new(any)), &jsonErr) {
+		// The offset is just past the character in error
+		line := 1 + bytes.Count(data[:max(jsonErr.Offset-1, 0)], []byte("\n"))
+		return &Error{Path: path, Line: line, Err: jsonErr}
+	}
+
+	m := yamlLine.FindStringSubmatch(err.Error())
+	if m == nil {
+		return &Error{Path: path, Err: err}
+	}
+	line, _ := strconv.Atoi(m[1])
+	return &Error{Path: path, Line: line, Err: errors.New(m[2])}
+}
+
+// errorf returns an Error at the node's line.
+func errorf(path string, node *yaml.Node, format string, args ...any) error {
+	return &Error{Path: path, Line: node.Line, Err: fmt.Errorf(format, args...)}
+}
+
+// Line returns the line the item starts on.
+func (it Item) Line() int {
+	return it.line
+}
+
+// Errorf returns an Error for a problem with the value of the item's field,
+// named by its key, at the line it was given on.
+func (it Item) Errorf(key, format string, args ...any) error {
+	line, ok := it.lines[key]
+	if !ok {
+		line = it.line
+	}
+	return &Error{Path: it.path, Line: line, Err: fmt.Errorf(format, args...)}
+}
@@ -0,0 +1,134 @@
+package playlist
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		title string
+		items []Item
+	}{
+		{
+			name: "yaml with title",
+			input: `title: Track A
+items:
+  - title: Keynote
+    duration: 45m
+    stages: ["20%:yellow", "10%:red,blink"]
+  - title: Lunch
+    until: "13:30"
+    theme: dracula
+`,
+			title: "Track A",
+			items: []Item{
+				{Title: "Keynote", Duration: "45m", Stages: []string{"20%:yellow", "10%:red,blink"}},
+				{Title: "Lunch", Until: "13:30", Theme: "dracula"},
+			},
+		},
+		{
+			name: "yaml list",
+			input: `- title: Talk
+  duration: 20m
+  stages: 2m:red
+`,
+			items: []Item{{Title: "Talk", Duration: "20m", Stages: []string{"2m:red"}}},
+		},
+		{
+			name: "json",
+			input: `{
+  "title": "Track B",
+  "items": [
+    {"title": "Talk", "duration": 1200},
+    {"title": "Q&A", "until": "2026-06-15T11:00:00"}
+  ]
+}`,
+			title: "Track B",
+			items: []Item{
+				{Title: "Talk", Duration: "1200"},
+				{Title: "Q&A", Until: "2026-06-15T11:00:00"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse("agenda", []byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.title, p.Title)
+			require.Len(t, p.Items, len(tt.items))
+			for i, want := range tt.items {
+				got := p.Items[i]
+				assert.Equal(t, want.Title, got.Title)
+				assert.Equal(t, want.Duration, got.Duration)
+				assert.Equal(t, want.Until, got.Until)
+				assert.Equal(t, want.Stages, got.Stages)
+				assert.Equal(t, want.Theme, got.Theme)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", "agenda: no items"},
+		{"syntax", "items:\n\t- title: Talk\n", "agenda:2: found character that cannot start any token"},
+		{"json syntax", "{\"items\": [\n{\"title\": \"Talk\",,}\n]}", "agenda:2: invalid character ','"},
+		{"json syntax on a later line", "[\n  {\"title\": \"Talk\", \"duration\": \"5m\"},\n  {\"title\": \"Break\" \"duration\": \"5m\"}\n]", "agenda:3: invalid character '\"' after object key:value pair"},
+		{"unknown top-level key", "title: A\nitem: []\n", "agenda:2: unknown key \"item\""},
+		{"no items key", "title: A\n", "agenda:1: no items"},
+		{"items not a list", "items: Talk\n", "agenda:1: items must be a list"},
+		{"item not a mapping", "- Talk\n", "agenda:1: item must be a mapping"},
+		{"unknown item key", "- title: Talk\n  duration: 5m\n  colour: red\n", "agenda:3: unknown key \"colour\" in item"},
+		{"no title", "- title: Talk\n  duration: 5m\n- duration: 5m\n", "agenda:3: item has no title"},
+		{"no duration", "- title: Talk\n", "agenda:1: item \"Talk\" needs a duration or until time"},
+		{"both duration and until", "- title: Talk\n  duration: 5m\n  until: \"13:00\"\n", "agenda:3: item \"Talk\" has both"},
+		{"empty duration", "- title: Talk\n  duration:\n", "agenda:2: duration must not be empty"},
+		{"stage not text", "- title: Talk\n  duration: 5m\n  stages:\n    - {at: 5m}\n", "agenda:4: stage must be text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("agenda", []byte(tt.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+
+			var perr *Error
+			assert.True(t, errors.As(err, &perr))
+		})
+	}
+}
+
+func TestItemErrorf(t *testing.T) {
+	p, err := Parse("agenda.yaml", []byte("- title: Talk\n  duration: soon\n"))
+	require.NoError(t, err)
+
+	item := p.Items[0]
+	assert.Equal(t, 1, item.Line())
+	assert.EqualError(t, item.Errorf("duration", "invalid duration: %s", item.Duration), "agenda.yaml:2: invalid duration: soon")
+	assert.EqualError(t, item.Errorf("stages", "bad stage"), "agenda.yaml:1: bad stage", "A field not given should use the item's line")
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agenda.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"title": "Talk", "duration": "5m"}]`), 0o644))
+
+	p, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Talk", p.Items[0].Title)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
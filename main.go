@@ -1,36 +1,126 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/alecthomas/kong"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/countdown/countdown/internal/command"
 	"github.com/countdown/countdown/internal/countdown"
+	"github.com/countdown/countdown/internal/figlet"
+	"github.com/countdown/countdown/internal/playlist"
+	"github.com/mattn/go-isatty"
 )
 
 var version = "dev"
 
+// Exit codes used in addition to the usual 0 for success.
+const (
+	exitError        = 1
+	exitTargetPassed = 2
+	exitAborted      = 3
+	exitTimedOut     = 124
+	exitCannotRun    = 127
+)
+
+// maxPrecision is the most decimal places which can be displayed.
+const maxPrecision = 3
+
+// errTargetPassed is returned when the time given with --until is not in the future.
+var errTargetPassed = errors.New("target time has already passed")
+
 // CLI defines the command-line interface.
 type CLI struct {
-	Version      bool   `short:"v" help:"Print the version number"`
-	Spinner      string `short:"s" default:"dot" help:"Spinner type" env:"COUNTDOWN_SPINNER" enum:"dot,line,minidot,jump,pulse,points,globe,moon,monkey,meter,hamburger,bomb,none"`
-	Title        string `default:"Liftoff in" help:"Text to display to user while counting" env:"COUNTDOWN_TITLE"`
-	Range        string `short:"r" default:"100..0" help:"Numbers to count from and to"`
-	TimeInterval int    `short:"t" default:"1" help:"Number of seconds between each iteration"`
-	Decrement    int    `short:"d" default:"1" help:"Number subtracted from current count at each iteration"`
-	FinalPhase   string `short:"f" default:"5" help:"Number at which the final phase starts. At this number, the foreground and background colors are swapped. Can be a number such as '5' or a percentage such as '10%'"`
-	Big          bool   `short:"b" help:"Display numbers using large ASCII art digits"`
+	Version          bool     `short:"v" help:"Print the version number"`
+	Spinner          string   `short:"s" default:"dot" help:"Spinner type" env:"COUNTDOWN_SPINNER" enum:"dot,line,minidot,jump,pulse,points,globe,moon,monkey,meter,hamburger,bomb,none"`
+	Title            string   `default:"Liftoff in" help:"Text to display to user while counting" env:"COUNTDOWN_TITLE"`
+	Range            string   `short:"r" default:"100..0" help:"Numbers to count from and to. Leave out the end, such as '0..', to count up until quit"`
+	Duration         string   `help:"Length of time to count down, such as '90s', '1h15m' or '01:30:00'"`
+	Until            string   `help:"Time or date to count down to, such as '14:00', '2026-12-31T23:59:59' or an RFC3339 timestamp with time zone"`
+	Sequence         string   `placeholder:"SEGMENTS" help:"Count down a sequence of segments in turn, as a comma-separated list of LABEL:DURATION or LABEL:DURATION:THEME, such as 'Work:25m,Break:5m'. The bell rings between segments"`
+	Repeat           int      `default:"1" help:"Number of times to count down the --sequence"`
+	Agenda           string   `type:"path" help:"YAML or JSON file listing items to count down in turn, each with a title and a duration or until time, shown as 'Now: X — Next: Y'. Move between items with n and p"`
+	TimeInterval     string   `short:"t" default:"1" help:"Time between each iteration, as a number of seconds such as '0.5' or a duration such as '250ms'"`
+	Decrement        float64  `short:"d" default:"1" help:"Number subtracted from current count at each iteration, such as '1' or '0.1'"`
+	Precision        int      `short:"p" default:"0" help:"Number of decimal places to display (0-3)"`
+	PrecisionFinal   bool     `help:"Only display decimal places during the final phase"`
+	FinalPhase       string   `short:"f" default:"5" help:"Number at which the final phase starts. At this number, the foreground and background colors are swapped. Can be a number such as '5' or a percentage such as '10%'"`
+	Stage            []string `sep:"none" placeholder:"THRESHOLD:STYLE" help:"Change the style from a threshold on, replacing the final phase's style. --final-phase still sets where the final_phase_entered event, --precision-final and the 's' key apply. Repeat for more stages. The threshold is a number, duration or percentage as for --final-phase, and the style is a comma-separated list of a color, 'bg=COLOR', 'blink', 'spinner=TYPE' and 'title=TEXT', such as '20%:yellow' or '10%:red,blink,title=Wrap up'"`
+	Overtime         bool     `help:"On reaching the end, carry on counting up with a '+' sign until quit, to show how far over time it is"`
+	OvertimeMax      string   `placeholder:"AMOUNT" help:"Stop counting overtime after this much, such as '5m', '300' or '50%' of the range. Needs --overtime"`
+	Big              bool     `short:"b" help:"Display numbers using large ASCII art digits"`
+	BigTitle         bool     `help:"Display the title in large letters too, beside the count when the terminal is wide enough and above it otherwise. Implies --big"`
+	DigitStyle       string   `default:"solid" enum:"solid,gradient,vertical,rainbow,changed" help:"How to color the --big digits: 'solid', a 'gradient' across or 'vertical' gradient down them, 'rainbow', or only the digits which 'changed'. Stages keep their own style"`
+	DigitColors      string   `placeholder:"COLOR[,COLOR]" help:"Colors for --digit-style: the two ends of a gradient, or the color of the changed digits. A purple and pink gradient is used by default"`
+	ColorFrom        string   `placeholder:"COLOR" help:"Color of the count at the start, blending smoothly into --color-to as it nears the end in place of the final phase. A hex code, ANSI number or color name"`
+	ColorTo          string   `placeholder:"COLOR" help:"Color of the count at the end, blending from --color-from"`
+	Font             string   `default:"rounded" enum:"block,braille,double,rounded,seven-segment,small" help:"Font of the --big digits: rounded, double, seven-segment, block, small or braille"`
+	FontFile         string   `type:"path" placeholder:"PATH" help:"FIGlet (.flf) font file for the --big digits, replacing --font. Characters it has no glyph for are drawn as they are"`
+	Display          string   `default:"number" help:"What shows the count: 'number', 'bar' or 'percent', or a comma-separated combination such as 'number,bar'"`
+	Progress         bool     `help:"Show a progress bar beneath the count, as with --display number,bar"`
+	ProgressPosition string   `default:"below" enum:"below,beside" help:"Where to draw the progress bar: 'below' the count or 'beside' the title"`
+	ProgressColor    string   `placeholder:"COLOR[,COLOR]" help:"Color of the progress bar, or two colors for a gradient between them. A pink and purple gradient is used by default"`
+	Align            string   `default:"left" enum:"left,center,right" help:"Where to place the countdown across the terminal"`
+	Valign           string   `default:"top" enum:"top,center,bottom" help:"Where to place the countdown up and down the terminal, with --fullscreen"`
+	Fullscreen       bool     `help:"Clear the terminal for the countdown, restoring it afterwards. Combine with --align center --valign center for a projector"`
+	Theme            string   `default:"default" help:"Color theme: default, dracula, solarized, solarized-light, solarized-dark, high-contrast, monochrome, or the name of a file in the themes directory beside the config file, $XDG_CONFIG_HOME/countdown/themes by default. Light or dark colors are picked to suit the terminal" env:"COUNTDOWN_THEME"`
+	Plain            bool     `help:"Print one plain line per change instead of drawing on the terminal. Used automatically when output is not a terminal" env:"COUNTDOWN_PLAIN"`
+	Exec             string   `short:"e" help:"Shell command to run when the countdown completes. Countdown exits with its exit status. A command can also be given after '--'"`
+	Wrap             bool     `short:"w" help:"Run the command while counting down instead of afterwards. It is terminated if the countdown completes first, and the countdown stops if it exits first"`
+	Grace            string   `default:"5s" help:"Time a wrapped command has to exit after SIGTERM before it is killed"`
+	OnSignal         string   `default:"show" enum:"show,exit,ignore" help:"What SIGTERM, SIGINT, SIGHUP and SIGQUIT do: 'show' displays (killed) for a moment then exits, 'exit' exits immediately, 'ignore' carries on"`
+	Adjust           string   `default:"10%" help:"Amount added or subtracted by the + and - keys. Can be a number such as '5', a duration such as '1m' or a percentage of the range such as '10%'"`
+	Output           string   `short:"o" default:"tui" enum:"tui,json" help:"What to write to standard output: 'tui' draws the countdown, 'json' writes one JSON event per line and draws the countdown on standard error if it is a terminal. The output of a command given after '--' goes to standard error too"`
+	EventsFile       string   `type:"path" help:"File to write JSON events to, one per line, while drawing the countdown as usual"`
+	Config           string   `type:"path" help:"Configuration file to use instead of config.toml or config.yaml in $XDG_CONFIG_HOME/countdown" env:"COUNTDOWN_CONFIG"`
+	Profile          string   `help:"Named profile from the configuration file to apply over its defaults" env:"COUNTDOWN_PROFILE"`
 
 	SpinnerStyle SpinnerStyle `embed:"" prefix:"spinner."`
 	TitleStyle   TitleStyle   `embed:"" prefix:"title."`
 	Padding      string       `default:"0 0" help:"Padding" env:"COUNTDOWN_PADDING"`
+
+	Count     CountCmd     `cmd:"" default:"withargs" help:"Count down, as when no command is given"`
+	Stopwatch StopwatchCmd `cmd:"" help:"Count up the time elapsed until quit, recording laps with 'l'"`
+	Configure ConfigCmd    `cmd:"" name:"config" help:"Work with the configuration"`
+}
+
+// DurationArg is the length of time to count down, given as an argument.
+type DurationArg struct {
+	Length string `arg:"" optional:"" name:"duration" help:"Length of time to count down (same as --duration)"`
+}
+
+// CountCmd counts down, and is run when no command is given.
+type CountCmd struct {
+	DurationArg `embed:""`
+}
+
+// StopwatchCmd counts up the time elapsed.
+type StopwatchCmd struct{}
+
+// ConfigCmd groups the commands for the configuration.
+type ConfigCmd struct {
+	Show ConfigShowCmd `cmd:"" help:"Print the configuration the other arguments would give, with where each setting comes from"`
+}
+
+// ConfigShowCmd prints the effective configuration.
+type ConfigShowCmd struct {
+	DurationArg `embed:""`
 }
 
 // SpinnerStyle defines styling for the spinner.
 type SpinnerStyle struct {
-	Foreground string `default:"212" help:"Foreground Color" env:"COUNTDOWN_SPINNER_FOREGROUND"`
+	Foreground string `default:"" help:"Foreground Color" env:"COUNTDOWN_SPINNER_FOREGROUND"`
 	Background string `default:"" help:"Background Color" env:"COUNTDOWN_SPINNER_BACKGROUND"`
 }
 
@@ -41,29 +131,206 @@ type TitleStyle struct {
 }
 
 func main() {
+	args, commandArgs := splitCommand(os.Args[1:])
+
 	var cli CLI
-	ctx := kong.Parse(&cli,
+	resolver := &configResolver{dir: configDir()}
+	parser := kong.Must(&cli,
 		kong.Name("countdown"),
-		kong.Description("Display spinner while displaying a number which counts downward"),
+		kong.Description("Display spinner while displaying a number which counts downward."),
 		kong.UsageOnError(),
+		kong.Resolvers(resolver),
 	)
+	ctx, err := parser.Parse(args)
+	parser.FatalIfErrorf(err)
+
+	stopwatch := ctx.Selected().Name == "stopwatch"
+	if ctx.Selected().Name == "show" {
+		if err := showConfig(os.Stdout, ctx, resolver); err != nil {
+			parser.FatalIfErrorf(err)
+		}
+		os.Exit(0)
+	}
 
 	if cli.Version {
 		fmt.Printf("countdown %s\n", version)
 		os.Exit(0)
 	}
 
-	// Parse range
-	start, end, err := parseRange(cli.Range)
+	if cli.Count.Length != "" {
+		if cli.Duration != "" {
+			ctx.FatalIfErrorf(fmt.Errorf("duration given both as argument and --duration"))
+		}
+		cli.Duration = cli.Count.Length
+	}
+	sources := 0
+	for _, given := range []bool{isFlagSet(ctx, "range"), cli.Duration != "", cli.Until != "", cli.Sequence != "", cli.Agenda != ""} {
+		if given {
+			sources++
+		}
+	}
+	if sources > 1 {
+		ctx.FatalIfErrorf(fmt.Errorf("only one of --range, --duration, --until, --sequence and --agenda can be used"))
+	}
+	if stopwatch && sources > 0 {
+		ctx.FatalIfErrorf(fmt.Errorf("stopwatch can't be used with --range, --duration, --until, --sequence or --agenda"))
+	}
+
+	// Command to run on completion
+	var cmd *exec.Cmd
+	switch {
+	case cli.Exec != "" && len(commandArgs) > 0:
+		ctx.FatalIfErrorf(fmt.Errorf("--exec can't be used with a command after '--'"))
+	case cli.Exec != "":
+		cmd = command.Shell(cli.Exec)
+	case len(commandArgs) > 0:
+		cmd = command.Args(commandArgs)
+	}
+
+	if cli.Wrap && cmd == nil {
+		ctx.FatalIfErrorf(fmt.Errorf("--wrap needs a command, given with --exec or after '--'"))
+	}
+	grace, err := parseInterval(cli.Grace)
+	if err != nil {
+		ctx.FatalIfErrorf(fmt.Errorf("invalid grace period: %s", cli.Grace))
+	}
+
+	// Parse interval
+	interval, err := parseInterval(cli.TimeInterval)
 	if err != nil {
 		ctx.FatalIfErrorf(err)
 	}
 
+	// Counts are held in units of 10^-precision, so that 47 at precision 1 is 4.7
+	if cli.Precision < 0 || cli.Precision > maxPrecision {
+		ctx.FatalIfErrorf(fmt.Errorf("invalid precision: %d (expected 0-%d)", cli.Precision, maxPrecision))
+	}
+	scale := countdown.Scale(cli.Precision)
+	unit := time.Second / time.Duration(scale)
+
+	limits := thresholds{finalPhase: cli.FinalPhase, stages: cli.Stage, scale: scale}
+	format := countdown.FormatNumber
+	var start, end, step int
+	var endless bool
+	var deadline time.Time
+	var sequence []countdown.Segment
+	var agendaTitle string
+	switch {
+	case stopwatch:
+		// Count up the time elapsed
+		endless = true
+		format = countdown.FormatClock
+	case cli.Until != "":
+		// Parse target time, counting down the time remaining
+		now := time.Now()
+		deadline, err = parseUntil(cli.Until, now)
+		if errors.Is(err, errTargetPassed) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitTargetPassed)
+		}
+		if err != nil {
+			ctx.FatalIfErrorf(err)
+		}
+		start, end = ceilUnits(deadline.Sub(now), unit), 0
+		format = countdown.FormatClock
+	case cli.Sequence != "":
+		// Parse segments, each counting down like a duration
+		if cli.Repeat < 1 {
+			ctx.FatalIfErrorf(fmt.Errorf("invalid repeat: %d (must be at least 1)", cli.Repeat))
+		}
+		sequence, err = parseSequence(cli.Sequence, unit, resolver.themeDir())
+		if err != nil {
+			ctx.FatalIfErrorf(err)
+		}
+		start, end = sequence[0].Length, 0
+		format = countdown.FormatClock
+	case cli.Agenda != "":
+		// Load the agenda, whose items count down like durations or until times
+		sequence, agendaTitle, err = loadAgenda(cli.Agenda, unit, resolver.themeDir(), time.Now(), limits)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		start, end = sequence[0].Length, 0
+		format = countdown.FormatClock
+	case cli.Duration != "":
+		// Parse duration, counting down the time remaining
+		d, err := parseDuration(cli.Duration)
+		if err != nil {
+			ctx.FatalIfErrorf(err)
+		}
+		start, end = ceilUnits(d, unit), 0
+		format = countdown.FormatClock
+	default:
+		// Parse range, which counts up endlessly without an end
+		if start, endless, err = parseOpenRange(cli.Range); err == nil && !endless {
+			start, end, err = parseRange(cli.Range)
+		}
+		if err != nil {
+			ctx.FatalIfErrorf(err)
+		}
+		if endless {
+			end = start
+		}
+		start, end = start*scale, end*scale
+		step = int(math.Round(cli.Decrement * float64(scale)))
+		if step < 1 {
+			ctx.FatalIfErrorf(fmt.Errorf("invalid decrement: %v (too small for precision %d)", cli.Decrement, cli.Precision))
+		}
+	}
+
+	if format == countdown.FormatClock {
+		// Time passes at one unit per unit of time, updating as often as
+		// the precision shows unless an interval is given
+		if !isFlagSet(ctx, "time-interval") {
+			interval = unit
+		}
+		step = max(int(interval/unit), 1)
+	}
+
 	// Parse final phase
-	finalPhase, err := parseFinalPhase(cli.FinalPhase, start, end)
+	finalPhase, err := parseFinalPhase(cli.FinalPhase, start, end, scale)
+	if err != nil {
+		ctx.FatalIfErrorf(err)
+	}
+
+	stages, err := parseStages(cli.Stage, start, end, scale)
+	if err != nil {
+		ctx.FatalIfErrorf(err)
+	}
+
+	if cli.Sequence != "" {
+		// Work out the final phase and stages from each segment's own length
+		for i := range sequence {
+			if err := limits.apply(&sequence[i], cli.Stage); err != nil {
+				ctx.FatalIfErrorf(err)
+			}
+		}
+		sequence = slices.Repeat(sequence, cli.Repeat)
+	}
+
+	if endless && cli.Overtime {
+		ctx.FatalIfErrorf(fmt.Errorf("--overtime can't be used when counting up endlessly"))
+	}
+	var overtimeMax int
+	if cli.OvertimeMax != "" {
+		if !cli.Overtime {
+			ctx.FatalIfErrorf(fmt.Errorf("--overtime-max needs --overtime"))
+		}
+		if overtimeMax, err = parseAmount(cli.OvertimeMax, start, end, scale); err != nil {
+			ctx.FatalIfErrorf(err)
+		}
+	}
+
+	// Parse adjustment
+	adjust, err := parseAmount(cli.Adjust, start, end, scale)
 	if err != nil {
 		ctx.FatalIfErrorf(err)
 	}
+	if endless && !isFlagSet(ctx, "adjust") {
+		// There is no range to take a percentage of
+		adjust = 10 * scale
+	}
 
 	// Parse padding
 	padV, padH, err := parsePadding(cli.Padding)
@@ -71,13 +338,58 @@ func main() {
 		ctx.FatalIfErrorf(err)
 	}
 
+	shown, err := parseDisplay(cli.Display)
+	if err != nil {
+		ctx.FatalIfErrorf(err)
+	}
+	if cli.Progress {
+		shown |= countdown.DisplayBar
+	}
+	digitColors, err := parseColors("digit-colors", cli.DigitColors, 2)
+	if err != nil {
+		ctx.FatalIfErrorf(err)
+	}
+	if (cli.ColorFrom == "") != (cli.ColorTo == "") {
+		ctx.FatalIfErrorf(fmt.Errorf("--color-from and --color-to must be given together"))
+	}
+	if _, err := parseColors("color-from", cli.ColorFrom, 1); err != nil {
+		ctx.FatalIfErrorf(err)
+	}
+	if _, err := parseColors("color-to", cli.ColorTo, 1); err != nil {
+		ctx.FatalIfErrorf(err)
+	}
+	progressColors, err := parseColors("progress-color", cli.ProgressColor, 2)
+	if err != nil {
+		ctx.FatalIfErrorf(err)
+	}
+
+	theme, err := loadTheme(cli.Theme, resolver.themeDir())
+	if err != nil {
+		ctx.FatalIfErrorf(fmt.Errorf("--theme: %w", err))
+	}
+
+	// The flag's enum only allows built-in fonts
+	font, _ := countdown.LookupFont(cli.Font)
+	if cli.FontFile != "" {
+		ff, err := figlet.Load(cli.FontFile)
+		if err != nil {
+			ctx.FatalIfErrorf(fmt.Errorf("--font-file: %w", err))
+		}
+		font = countdown.FIGletFont(strings.TrimSuffix(filepath.Base(cli.FontFile), filepath.Ext(cli.FontFile)), ff)
+	}
+
+	title := cli.Title
+	if stopwatch && !isFlagSet(ctx, "title") && os.Getenv("COUNTDOWN_TITLE") == "" {
+		title = "Elapsed"
+	}
+
 	config := countdown.Config{
 		SpinnerType:       cli.Spinner,
-		Title:             cli.Title,
+		Title:             title,
 		Start:             start,
 		End:               end,
-		TimeInterval:      cli.TimeInterval,
-		Decrement:         cli.Decrement,
+		TimeInterval:      interval,
+		Decrement:         step,
 		FinalPhase:        finalPhase,
 		SpinnerForeground: cli.SpinnerStyle.Foreground,
 		SpinnerBackground: cli.SpinnerStyle.Background,
@@ -85,13 +397,271 @@ func main() {
 		TitleBackground:   cli.TitleStyle.Background,
 		PaddingVertical:   padV,
 		PaddingHorizontal: padH,
-		Big:               cli.Big,
+		Big:               cli.Big || cli.BigTitle,
+		BigTitle:          cli.BigTitle,
+		DigitStyle:        digitStyles[cli.DigitStyle],
+		DigitColors:       digitColors,
+		ColorFrom:         cli.ColorFrom,
+		ColorTo:           cli.ColorTo,
+		Font:              font,
+		Format:            format,
+		Deadline:          deadline,
+		Precision:         cli.Precision,
+		PrecisionFinal:    cli.PrecisionFinal,
+		Adjust:            adjust,
+		OnSignal:          signalActions[cli.OnSignal],
+		Theme:             theme,
+		Stages:            stages,
+		Overtime:          cli.Overtime,
+		OvertimeMax:       overtimeMax,
+		Endless:           endless,
+		Sequence:          sequence,
+		Agenda:            cli.Agenda != "",
+		AgendaTitle:       agendaTitle,
+		Display:           shown,
+		ProgressBeside:    cli.ProgressPosition == "beside",
+		ProgressColors:    progressColors,
+		Align:             positions[cli.Align],
+		VAlign:            positions[cli.Valign],
+		Fullscreen:        cli.Fullscreen,
+	}
+
+	// Where the countdown is drawn and where events go, with a command's
+	// output kept out of the events
+	display, events, commandOut := os.Stdout, io.Writer(nil), os.Stdout
+	if cli.Output == "json" {
+		display, events, commandOut = os.Stderr, os.Stdout, os.Stderr
+	}
+	// exit closes the events file before exiting, reporting any error
+	// writing it
+	exit := os.Exit
+	if cli.EventsFile != "" {
+		f, err := os.Create(cli.EventsFile)
+		if err != nil {
+			ctx.FatalIfErrorf(fmt.Errorf("--events-file: %w", err))
+		}
+		file := &eventsFile{File: f}
+		events = file
+		exit = func(code int) {
+			if err := file.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --events-file: %v\n", err)
+				if code == 0 {
+					code = exitError
+				}
+			}
+			os.Exit(code)
+		}
+	}
+	config.Output = display
+	config.Plain = cli.Plain || !isTerminal(display)
+	if cli.Output == "json" && !cli.Plain && !isTerminal(display) {
+		// Nowhere to draw, so only write events
+		config.Output = io.Discard
+	}
+	if events != nil {
+		config.OnEvent = countdown.JSONEvents(events)
 	}
 
-	if err := countdown.Run(config); err != nil {
+	if cli.Wrap {
+		exit(runWrapped(config, cmd, grace, commandOut))
+	}
+
+	result, err := countdown.Run(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(exitError)
+	}
+
+	if endless {
+		// Quitting is how a stopwatch ends, so print the laps and total for
+		// scripts rather than treating it as aborted. JSON events already
+		// include the laps.
+		if cli.Output != "json" {
+			if err := printLaps(os.Stdout, result); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				exit(exitError)
+			}
+		}
+		if result.Outcome == countdown.Aborted {
+			result.Outcome = countdown.Completed
+		}
+	}
+
+	switch result.Outcome {
+	case countdown.Aborted:
+		exit(exitAborted)
+	case countdown.Killed:
+		exit(signalExitCode(result.Signal))
+	}
+
+	if cmd != nil {
+		status, err := command.Run(cmd, commandOut)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(exitCannotRun)
+		}
+		exit(status)
+	}
+	exit(0)
+}
+
+// runWrapped starts the command, with its output written to out, and counts
+// down while it runs. The command is terminated if the countdown completes
+// first, and the countdown stops early if the command exits first. It returns
+// the exit status for countdown.
+func runWrapped(config countdown.Config, cmd *exec.Cmd, grace time.Duration, out *os.File) int {
+	// The command's output is printed above the countdown rather than
+	// through it
+	stdout, stderr := countdown.NewPrinter(out), countdown.NewPrinter(os.Stderr)
+	child, err := command.Start(cmd, stdout, stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCannotRun
+	}
+	config.Stop = child.Done()
+	config.Printers = []*countdown.Printer{stdout, stderr}
+
+	result, err := countdown.Run(config)
+	if err != nil {
+		child.Terminate(grace)
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		return exitError
+	}
+
+	switch result.Outcome {
+	case countdown.Stopped:
+		status, err := child.ExitStatus()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitError
+		}
+		return status
+	case countdown.Aborted:
+		child.Terminate(grace)
+		return exitAborted
+	case countdown.Killed:
+		child.Terminate(grace)
+		return signalExitCode(result.Signal)
+	}
+
+	// Out of time
+	child.Terminate(grace)
+	return exitTimedOut
+}
+
+// eventsFile is the file given with --events-file. JSONEvents ignores errors
+// writing events so as not to interrupt the countdown, so the first is kept
+// to report on closing.
+type eventsFile struct {
+	*os.File
+	err error
+}
+
+// Write writes to the file, keeping the first error.
+func (f *eventsFile) Write(b []byte) (int, error) {
+	n, err := f.File.Write(b)
+	if f.err == nil {
+		f.err = err
+	}
+	return n, err
+}
+
+// Close closes the file and returns the first error writing or closing it.
+func (f *eventsFile) Close() error {
+	err := f.File.Close()
+	if f.err != nil {
+		return f.err
+	}
+	return err
+}
+
+// signalActions maps --on-signal values to what the countdown does.
+var signalActions = map[string]countdown.SignalAction{
+	"show":   countdown.SignalShow,
+	"exit":   countdown.SignalExit,
+	"ignore": countdown.SignalIgnore,
+}
+
+// positions maps --align and --valign values to where the countdown is placed.
+var positions = map[string]lipgloss.Position{
+	"left":   lipgloss.Left,
+	"right":  lipgloss.Right,
+	"top":    lipgloss.Top,
+	"bottom": lipgloss.Bottom,
+	"center": lipgloss.Center,
+}
+
+// digitStyles maps --digit-style values to how the big digits are colored.
+var digitStyles = map[string]countdown.DigitStyle{
+	"solid":    countdown.DigitStyleSolid,
+	"gradient": countdown.DigitStyleGradient,
+	"vertical": countdown.DigitStyleVertical,
+	"rainbow":  countdown.DigitStyleRainbow,
+	"changed":  countdown.DigitStyleChanged,
+}
+
+// displayNames maps the names used by --display to what they show.
+var displayNames = map[string]countdown.Display{
+	"number":  countdown.DisplayNumber,
+	"bar":     countdown.DisplayBar,
+	"percent": countdown.DisplayPercent,
+}
+
+// parseDisplay parses a comma-separated combination of display names.
+func parseDisplay(val string) (countdown.Display, error) {
+	var display countdown.Display
+	for _, name := range strings.Split(val, ",") {
+		d, ok := displayNames[strings.TrimSpace(name)]
+		if !ok {
+			return 0, fmt.Errorf("invalid display: %s (expected number, bar or percent, or a combination such as number,bar)", val)
+		}
+		display |= d
 	}
+	return display, nil
+}
+
+// parseColors parses a comma-separated list of up to limit colors, given as
+// the value of the named flag. An empty value gives no colors.
+func parseColors(name, val string, limit int) ([]string, error) {
+	if val == "" {
+		return nil, nil
+	}
+	colors := strings.Split(val, ",")
+	if len(colors) > limit {
+		return nil, fmt.Errorf("invalid %s: %s (too many colors, at most %d)", name, val, limit)
+	}
+	for i, c := range colors {
+		colors[i] = strings.TrimSpace(c)
+		if !countdown.ValidColor(colors[i]) {
+			return nil, fmt.Errorf("invalid %s: %s (expected a hex code, ANSI number or color name)", name, colors[i])
+		}
+	}
+	return colors, nil
+}
+
+// isTerminal reports whether the file is a terminal.
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// splitCommand splits the arguments at the first "--" into countdown's own
+// arguments and a command to run.
+func splitCommand(args []string) ([]string, []string) {
+	for i, arg := range args {
+		if arg == "--" {
+			return args[:i], args[i+1:]
+		}
+	}
+	return args, nil
+}
+
+// signalExitCode returns the conventional exit status for a process ended by
+// the signal: 128 plus the signal number.
+func signalExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return exitError
 }
 
 // parseRange parses a range string like "100..0" into start and end values.
@@ -114,8 +684,150 @@ func parseRange(r string) (int, int, error) {
 	return start, end, nil
 }
 
-// parseFinalPhase parses the final phase value which can be a number or percentage.
-func parseFinalPhase(val string, start, end int) (int, error) {
+// parseOpenRange parses a range without an end, such as "0..", into its start.
+// It reports false for any other range.
+func parseOpenRange(r string) (int, bool, error) {
+	startStr, ok := strings.CutSuffix(strings.TrimSpace(r), "..")
+	if !ok {
+		return 0, false, nil
+	}
+	start, err := strconv.Atoi(strings.TrimSpace(startStr))
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid start value in range: %s", startStr)
+	}
+	return start, true, nil
+}
+
+// printLaps writes each lap as its number, split and total, separated by tabs,
+// followed by the total count.
+func printLaps(w io.Writer, result countdown.Result) error {
+	for i, lap := range result.Laps {
+		if _, err := fmt.Fprintf(w, "%d\t%s\t%s\n", i+1, lap.Split, lap.Count); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "total\t%s\n", result.Count)
+	return err
+}
+
+// parseDuration parses a duration written either in Go style ("1h15m", "90s"),
+// in colon style ("01:30:00" or "05:30"), or as a bare number of seconds.
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	if strings.Contains(s, ":") {
+		parts := strings.Split(s, ":")
+		if len(parts) > 3 {
+			return 0, fmt.Errorf("invalid duration: %s (expected format: HH:MM:SS or MM:SS)", s)
+		}
+		var total time.Duration
+		for _, part := range parts {
+			n, err := strconv.Atoi(part)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid duration: %s (expected format: HH:MM:SS or MM:SS)", s)
+			}
+			total = total*60 + time.Duration(n)
+		}
+		return total * time.Second, nil
+	}
+
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 {
+			return 0, fmt.Errorf("invalid duration: %s (must not be negative)", s)
+		}
+		return time.Duration(n) * time.Second, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %s", s)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid duration: %s (must not be negative)", s)
+	}
+	return d, nil
+}
+
+// untilClockLayouts are the time-of-day formats accepted by --until. They
+// refer to the next occurrence of that time, today or tomorrow.
+var untilClockLayouts = []string{"15:04", "15:04:05"}
+
+// untilDateLayouts are the local date and time formats accepted by --until.
+var untilDateLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseUntil parses the target of --until relative to now. A bare time of day
+// which has already passed today refers to tomorrow; any other target which is
+// not after now returns errTargetPassed.
+func parseUntil(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	for _, layout := range untilClockLayouts {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		// Build the target from calendar fields so that DST changes are respected
+		target := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location())
+		if !target.After(now) {
+			target = time.Date(now.Year(), now.Month(), now.Day()+1, t.Hour(), t.Minute(), t.Second(), 0, now.Location())
+		}
+		return target, nil
+	}
+
+	target, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		target = time.Time{}
+		for _, layout := range untilDateLayouts {
+			if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+				target = t
+				break
+			}
+		}
+		if target.IsZero() {
+			return time.Time{}, fmt.Errorf("invalid until value: %s (expected a time such as 14:00 or a date such as 2026-12-31T23:59:59)", s)
+		}
+	}
+
+	if !target.After(now) {
+		return time.Time{}, fmt.Errorf("%w: %s", errTargetPassed, s)
+	}
+	return target, nil
+}
+
+// ceilUnits converts a duration to a whole number of units, rounding up.
+func ceilUnits(d, unit time.Duration) int {
+	return int((d + unit - 1) / unit)
+}
+
+// parseInterval parses the time between iterations, given either as a number
+// of seconds ("1", "0.5") or as a duration ("250ms").
+func parseInterval(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		secs, ferr := strconv.ParseFloat(s, 64)
+		if ferr != nil {
+			return 0, fmt.Errorf("invalid time interval: %s", s)
+		}
+		d = time.Duration(secs * float64(time.Second))
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid time interval: %s (must be greater than zero)", s)
+	}
+	return d, nil
+}
+
+// parseFinalPhase parses the final phase value which can be a number, a
+// duration in seconds, or a percentage. Start, end and the result are counts
+// multiplied by scale, which is 10^precision.
+func parseFinalPhase(val string, start, end, scale int) (int, error) {
 	val = strings.TrimSpace(val)
 
 	if strings.HasSuffix(val, "%") {
@@ -129,12 +841,248 @@ func parseFinalPhase(val string, start, end int) (int, error) {
 		return end + (total * percent / 100), nil
 	}
 
-	num, err := strconv.Atoi(val)
+	num, err := strconv.ParseFloat(val, 64)
+	if err != nil || math.IsInf(num, 0) || math.IsNaN(num) {
+		// Durations such as '30s' are counted in seconds
+		d, derr := parseDuration(val)
+		if derr != nil {
+			return 0, fmt.Errorf("invalid final-phase value: %s", val)
+		}
+		return ceilUnits(d, time.Second/time.Duration(scale)), nil
+	}
+
+	return int(math.Round(num * float64(scale))), nil
+}
+
+// parseStage parses a stage given as THRESHOLD:STYLE. The threshold is
+// anything parseFinalPhase accepts, or 'overtime' for a stage applying in
+// overtime, and the style is a comma-separated list of a foreground color,
+// optionally as 'fg=COLOR', 'bg=COLOR', 'blink', 'spinner=TYPE' and
+// 'title=TEXT', which takes the rest of the list. Since a threshold such as
+// '01:30' can itself contain colons, the last colon which leaves a valid
+// threshold separates the two.
+func parseStage(val string, start, end, scale int) (countdown.Stage, error) {
+	var stage countdown.Stage
+	var style string
+	found := false
+	if after, ok := strings.CutPrefix(val, "overtime:"); ok {
+		stage.Overtime, style, found = true, after, true
+	} else {
+		for i := strings.LastIndex(val, ":"); i >= 0; i = strings.LastIndex(val[:i], ":") {
+			threshold, err := parseFinalPhase(val[:i], start, end, scale)
+			if err == nil {
+				stage.Threshold, style, found = threshold, val[i+1:], true
+				break
+			}
+		}
+	}
+	if !found {
+		return stage, fmt.Errorf("invalid stage %q, expected THRESHOLD:STYLE such as '10%%:red,blink'", val)
+	}
+
+	items := strings.Split(style, ",")
+	for i, item := range items {
+		item = strings.TrimSpace(item)
+		key, value, hasValue := strings.Cut(item, "=")
+		if key == "title" && hasValue {
+			// The title is the rest, so it can contain commas
+			_, stage.Title, _ = strings.Cut(strings.Join(items[i:], ","), "=")
+			break
+		}
+		switch {
+		case item == "":
+		case item == "blink":
+			stage.Blink = true
+		case !hasValue, key == "fg", key == "bg":
+			color := value
+			if !hasValue {
+				color = item
+			}
+			if !countdown.ValidColor(color) {
+				return stage, fmt.Errorf("invalid stage %q: unknown style or color %q", val, color)
+			}
+			if key == "bg" {
+				stage.Background = color
+			} else {
+				stage.Foreground = color
+			}
+		case key == "spinner":
+			if _, ok := countdown.SpinnerMap[value]; !ok {
+				return stage, fmt.Errorf("invalid stage %q: unknown spinner %q", val, value)
+			}
+			stage.Spinner = value
+		default:
+			return stage, fmt.Errorf("invalid stage %q: unknown style %q", val, item)
+		}
+	}
+	return stage, nil
+}
+
+// parseStages parses each --stage value.
+func parseStages(vals []string, start, end, scale int) ([]countdown.Stage, error) {
+	stages := make([]countdown.Stage, len(vals))
+	for i, val := range vals {
+		var err error
+		if stages[i], err = parseStage(val, start, end, scale); err != nil {
+			return nil, err
+		}
+	}
+	return stages, nil
+}
+
+// parseSequence parses a comma-separated list of segments, each written as
+// LABEL:DURATION or LABEL:DURATION:THEME, or as a bare duration. Lengths are
+// counted in units, and themes are loaded as by --theme from dir.
+func parseSequence(val string, unit time.Duration, dir string) ([]countdown.Segment, error) {
+	var segments []countdown.Segment
+	for _, item := range strings.Split(val, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			return nil, fmt.Errorf("invalid sequence: %s (empty segment)", val)
+		}
+
+		// A duration such as "05:30" may itself contain colons
+		label, rest, themeName := "", item, ""
+		if _, err := parseDuration(item); err != nil {
+			var ok bool
+			if label, rest, ok = strings.Cut(item, ":"); !ok {
+				return nil, fmt.Errorf("invalid segment: %s (expected LABEL:DURATION)", item)
+			}
+			if _, err := parseDuration(rest); err != nil {
+				if i := strings.LastIndex(rest, ":"); i >= 0 {
+					rest, themeName = rest[:i], rest[i+1:]
+				}
+			}
+		}
+
+		d, err := parseDuration(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid segment: %s: %w", item, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid segment: %s (duration must be greater than zero)", item)
+		}
+		seg := countdown.Segment{Label: strings.TrimSpace(label), Length: ceilUnits(d, unit)}
+		if themeName != "" {
+			if seg.Theme, err = loadTheme(themeName, dir); err != nil {
+				return nil, fmt.Errorf("invalid segment: %s: %w", item, err)
+			}
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+// loadAgenda loads the agenda file at path as a sequence, and its title, with
+// any stages given for an item worked out from its length. Times of day given as until
+// times are taken to be today, so that items whose time has passed are
+// skipped. Problems with an item's values are reported at their line.
+func loadAgenda(path string, unit time.Duration, dir string, now time.Time, limits thresholds) ([]countdown.Segment, string, error) {
+	p, err := playlist.Load(path)
 	if err != nil {
-		return 0, fmt.Errorf("invalid final-phase value: %s", val)
+		return nil, "", err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	segments := make([]countdown.Segment, len(p.Items))
+	for i, item := range p.Items {
+		seg := countdown.Segment{Label: item.Title}
+		if item.Until != "" {
+			if seg.Deadline, err = parseUntil(item.Until, today); err != nil {
+				return nil, "", item.Errorf("until", "%v", err)
+			}
+			// Only an estimate, until the item begins
+			seg.Length = max(ceilUnits(seg.Deadline.Sub(now), unit), 0)
+		} else {
+			d, err := parseDuration(item.Duration)
+			if err != nil {
+				return nil, "", item.Errorf("duration", "%v", err)
+			}
+			if d <= 0 {
+				return nil, "", item.Errorf("duration", "invalid duration: %s (must be greater than zero)", item.Duration)
+			}
+			seg.Length = ceilUnits(d, unit)
+		}
+
+		if item.Theme != "" {
+			if seg.Theme, err = loadTheme(item.Theme, dir); err != nil {
+				return nil, "", item.Errorf("theme", "%v", err)
+			}
+		}
+		stages := limits.stages
+		if item.Stages != nil {
+			if _, err := parseStages(item.Stages, seg.Length, 0, limits.scale); err != nil {
+				return nil, "", item.Errorf("stages", "%v", err)
+			}
+			stages = item.Stages
+		}
+		if err := limits.apply(&seg, stages); err != nil {
+			return nil, "", err
+		}
+		segments[i] = seg
+	}
+	return segments, p.Title, nil
+}
+
+// thresholds are the --final-phase and --stage values, for working out from
+// the length of each segment of a sequence.
+type thresholds struct {
+	finalPhase string
+	stages     []string
+	scale      int
+}
+
+// apply sets the final phase and the stages given of the segment, with
+// percentages taken of its length. A segment with a deadline is only as long
+// as the time left when it begins, so they are worked out again then.
+func (t thresholds) apply(seg *countdown.Segment, stages []string) error {
+	resolve := func(length int) (int, []countdown.Stage, error) {
+		finalPhase, err := parseFinalPhase(t.finalPhase, length, 0, t.scale)
+		if err != nil {
+			return 0, nil, err
+		}
+		parsed, err := parseStages(stages, length, 0, t.scale)
+		return finalPhase, parsed, err
+	}
+
+	var err error
+	if seg.FinalPhase, seg.Stages, err = resolve(seg.Length); err != nil {
+		return err
 	}
+	if !seg.Deadline.IsZero() {
+		seg.Thresholds = func(length int) (int, []countdown.Stage) {
+			// Only the length differs from the values checked above
+			finalPhase, stages, _ := resolve(length)
+			return finalPhase, stages
+		}
+	}
+	return nil
+}
+
+// parseAmount parses an amount which can be a number, a duration in seconds,
+// or a percentage of the range. Start, end and the result are counts
+// multiplied by scale, which is 10^precision.
+func parseAmount(val string, start, end, scale int) (int, error) {
+	val = strings.TrimSpace(val)
 
-	return num, nil
+	if strings.HasSuffix(val, "%") {
+		percent, err := strconv.Atoi(strings.TrimSuffix(val, "%"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid percentage: %s", val)
+		}
+		return abs(start-end) * percent / 100, nil
+	}
+
+	num, err := strconv.ParseFloat(val, 64)
+	if err != nil || math.IsInf(num, 0) || math.IsNaN(num) {
+		d, derr := parseDuration(val)
+		if derr != nil {
+			return 0, fmt.Errorf("invalid amount: %s", val)
+		}
+		return ceilUnits(d, time.Second/time.Duration(scale)), nil
+	}
+
+	return int(math.Round(num * float64(scale))), nil
 }
 
 // parsePadding parses padding string "vertical horizontal" into two values.
@@ -161,6 +1109,16 @@ func parsePadding(p string) (int, int, error) {
 	return 0, 0, fmt.Errorf("invalid padding format: %s (expected 'v h' or 'v')", p)
 }
 
+// isFlagSet reports whether the named flag was given on the command line.
+func isFlagSet(ctx *kong.Context, name string) bool {
+	for _, el := range ctx.Path {
+		if el.Flag != nil && el.Flag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func abs(x int) int {
 	if x < 0 {
 		return -x
@@ -1,14 +1,51 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/alecthomas/kong"
+	"github.com/countdown/countdown/internal/countdown"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// mainArgsEnv, when set, makes the test binary run countdown with the
+// arguments it holds, one per line, rather than the tests.
+const mainArgsEnv = "COUNTDOWN_TEST_ARGS"
+
+func TestMain(m *testing.M) {
+	if args, ok := os.LookupEnv(mainArgsEnv); ok {
+		os.Args = append([]string{"countdown"}, strings.Split(args, "\n")...)
+		main()
+	}
+	os.Exit(m.Run())
+}
+
+// runMain runs countdown with the arguments in a process of its own, as it
+// exits when done, and returns what it wrote to standard output.
+func runMain(t *testing.T, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), mainArgsEnv+"="+strings.Join(args, "\n"), "XDG_CONFIG_HOME="+t.TempDir())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	require.NoError(t, err, "stderr: %s", stderr.String())
+	return string(out)
+}
+
 func TestParseRange(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -42,6 +79,194 @@ func TestParseRange(t *testing.T) {
 	}
 }
 
+func TestParseOpenRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantStart int
+		wantOpen  bool
+		wantErr   bool
+	}{
+		{"from zero", "0..", 0, true, false},
+		{"with spaces", " 30 .. ", 30, true, false},
+		{"negative start", "-10..", -10, true, false},
+		{"closed range", "100..0", 0, false, false},
+		{"invalid start", "abc..", 0, false, true},
+		{"no start", "..", 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, open, err := parseOpenRange(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantOpen, open)
+		})
+	}
+}
+
+func TestPrintLaps(t *testing.T) {
+	var b bytes.Buffer
+	err := printLaps(&b, countdown.Result{
+		Count: "00:02:30",
+		Laps: []countdown.Lap{
+			{Count: "00:01:30", Split: "00:01:30"},
+			{Count: "00:02:15", Split: "00:00:45"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1\t00:01:30\t00:01:30\n2\t00:00:45\t00:02:15\ntotal\t00:02:30\n", b.String())
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"seconds", "90s", 90 * time.Second, false},
+		{"hours and minutes", "1h15m", 75 * time.Minute, false},
+		{"minutes and seconds", "5m30s", 330 * time.Second, false},
+		{"colon hours", "01:30:00", 90 * time.Minute, false},
+		{"colon minutes", "05:30", 330 * time.Second, false},
+		{"bare seconds", "45", 45 * time.Second, false},
+		{"with spaces", " 1h ", time.Hour, false},
+		{"too many colons", "1:2:3:4", 0, true},
+		{"invalid colon part", "1:xx", 0, true},
+		{"negative", "-5s", 0, true},
+		{"invalid", "soon", 0, true},
+		{"empty", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDuration(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseUntil(t *testing.T) {
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2026, 6, 15, 10, 30, 0, 0, zone)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr error
+	}{
+		{"later today", "14:00", time.Date(2026, 6, 15, 14, 0, 0, 0, zone), nil},
+		{"with seconds", "10:30:15", time.Date(2026, 6, 15, 10, 30, 15, 0, zone), nil},
+		{"past midnight", "09:00", time.Date(2026, 6, 16, 9, 0, 0, 0, zone), nil},
+		{"exactly now is tomorrow", "10:30", time.Date(2026, 6, 16, 10, 30, 0, 0, zone), nil},
+		{"local date and time", "2026-12-31T23:59:59", time.Date(2026, 12, 31, 23, 59, 59, 0, zone), nil},
+		{"local date with space", "2026-12-31 23:59", time.Date(2026, 12, 31, 23, 59, 0, 0, zone), nil},
+		{"date only", "2026-07-01", time.Date(2026, 7, 1, 0, 0, 0, 0, zone), nil},
+		{"rfc3339", "2026-06-15T12:00:00Z", time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC), nil},
+		{"past date", "2026-01-01T00:00:00", time.Time{}, errTargetPassed},
+		{"past rfc3339", "2026-06-15T08:00:00Z", time.Time{}, errTargetPassed},
+		{"invalid", "noon", time.Time{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseUntil(tt.input, now)
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.want.IsZero():
+				require.Error(t, err)
+				assert.NotErrorIs(t, err, errTargetPassed)
+			default:
+				require.NoError(t, err)
+				assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUntilAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Clocks spring forward at 02:00 on 8 March 2026, so the day is 23 hours long
+	now := time.Date(2026, 3, 7, 23, 0, 0, 0, loc)
+	got, err := parseUntil("22:00", now)
+	require.NoError(t, err)
+	assert.Equal(t, 22*time.Hour, got.Sub(now))
+
+	// Clocks fall back at 02:00 on 1 November 2026, so the day is 25 hours long
+	now = time.Date(2026, 10, 31, 23, 0, 0, 0, loc)
+	got, err = parseUntil("22:00", now)
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, got.Sub(now))
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"whole seconds", "1", time.Second, false},
+		{"fractional seconds", "0.5", 500 * time.Millisecond, false},
+		{"milliseconds", "250ms", 250 * time.Millisecond, false},
+		{"fractional duration", "0.5s", 500 * time.Millisecond, false},
+		{"minutes", "1m", time.Minute, false},
+		{"zero", "0", 0, true},
+		{"negative", "-1s", 0, true},
+		{"invalid", "fast", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseInterval(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseFinalPhaseWithPrecision(t *testing.T) {
+	tests := []struct {
+		name  string
+		val   string
+		start int
+		end   int
+		scale int
+		want  int
+	}{
+		{"absolute number", "5", 1000, 0, 10, 50},
+		{"fractional number", "2.5", 1000, 0, 10, 25},
+		{"percentage", "10%", 1000, 0, 10, 100},
+		{"duration", "10s", 3000, 0, 100, 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFinalPhase(tt.val, tt.start, tt.end, tt.scale)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestParseFinalPhase(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -55,13 +280,14 @@ func TestParseFinalPhase(t *testing.T) {
 		{"percentage 10%", "10%", 100, 0, 10, false},
 		{"percentage 50%", "50%", 100, 0, 50, false},
 		{"percentage with reverse", "10%", 0, 100, 110, false},
+		{"duration in seconds", "1m", 300, 0, 60, false},
 		{"invalid", "abc", 100, 0, 0, true},
 		{"invalid percent", "abc%", 100, 0, 0, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseFinalPhase(tt.val, tt.start, tt.end)
+			got, err := parseFinalPhase(tt.val, tt.start, tt.end, 1)
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
@@ -72,6 +298,143 @@ func TestParseFinalPhase(t *testing.T) {
 	}
 }
 
+func TestParseStage(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		start   int
+		end     int
+		want    countdown.Stage
+		wantErr bool
+	}{
+		{"color", "50:green", 100, 0, countdown.Stage{Threshold: 50, Foreground: "green"}, false},
+		{"percentage and blink", "10%:red,blink", 100, 0, countdown.Stage{Threshold: 10, Foreground: "red", Blink: true}, false},
+		{"colon duration", "01:30:fg=#ff0000,bg=0", 300, 0, countdown.Stage{Threshold: 90, Foreground: "#ff0000", Background: "0"}, false},
+		{"spinner", "5:spinner=bomb", 10, 0, countdown.Stage{Threshold: 5, Spinner: "bomb"}, false},
+		{"title with commas and colons", "20%:yellow,title=Nearly done: wrap up, please", 100, 0, countdown.Stage{Threshold: 20, Foreground: "yellow", Title: "Nearly done: wrap up, please"}, false},
+		{"blink only", "3:blink", 10, 0, countdown.Stage{Threshold: 3, Blink: true}, false},
+		{"overtime", "overtime:magenta,title=Over by", 10, 0, countdown.Stage{Overtime: true, Foreground: "magenta", Title: "Over by"}, false},
+		{"no style", "10", 100, 0, countdown.Stage{}, true},
+		{"invalid threshold", "soon:red", 100, 0, countdown.Stage{}, true},
+		{"unknown spinner", "5:spinner=wheel", 10, 0, countdown.Stage{}, true},
+		{"unknown style", "5:glow=yes", 10, 0, countdown.Stage{}, true},
+		{"misspelled style", "50%:blinky", 100, 0, countdown.Stage{}, true},
+		{"unsupported style", "50%:bold", 100, 0, countdown.Stage{}, true},
+		{"invalid foreground", "5:fg=#ff00", 10, 0, countdown.Stage{}, true},
+		{"invalid background", "5:red,bg=gren", 10, 0, countdown.Stage{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStage(tt.input, tt.start, tt.end, 1)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := parseStage("50%:blinky", 100, 0, 1)
+	assert.EqualError(t, err, `invalid stage "50%:blinky": unknown style or color "blinky"`)
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		val     string
+		start   int
+		end     int
+		scale   int
+		want    int
+		wantErr bool
+	}{
+		{"number", "5", 100, 0, 1, 5, false},
+		{"fractional number", "0.5", 1000, 0, 10, 5, false},
+		{"duration", "1m", 1500, 0, 1, 60, false},
+		{"duration with precision", "1m", 15000, 0, 10, 600, false},
+		{"percentage", "10%", 1500, 0, 1, 150, false},
+		{"percentage counting up", "10%", 0, 200, 1, 20, false},
+		{"invalid", "lots", 100, 0, 1, 0, true},
+		{"invalid percent", "x%", 100, 0, 1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAmount(tt.val, tt.start, tt.end, tt.scale)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseDisplay(t *testing.T) {
+	tests := []struct {
+		name    string
+		val     string
+		want    countdown.Display
+		wantErr bool
+	}{
+		{"number", "number", countdown.DisplayNumber, false},
+		{"bar", "bar", countdown.DisplayBar, false},
+		{"percent", "percent", countdown.DisplayPercent, false},
+		{"combination", "number,bar", countdown.DisplayNumber | countdown.DisplayBar, false},
+		{"all", "bar,percent,number", countdown.DisplayNumber | countdown.DisplayBar | countdown.DisplayPercent, false},
+		{"repeated", "bar,bar", countdown.DisplayBar, false},
+		{"spaces", " number , percent ", countdown.DisplayNumber | countdown.DisplayPercent, false},
+		{"invalid name", "number,graph", 0, true},
+		{"wrong case", "Number", 0, true},
+		{"empty part", "number,", 0, true},
+		{"empty", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDisplay(tt.val)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseColors(t *testing.T) {
+	tests := []struct {
+		name    string
+		val     string
+		limit   int
+		want    []string
+		wantErr string
+	}{
+		{"empty", "", 2, nil, ""},
+		{"one", "#ff0000", 1, []string{"#ff0000"}, ""},
+		{"two", "red, 33", 2, []string{"red", "33"}, ""},
+		{"too many", "red,blue", 1, nil, "invalid test: red,blue (too many colors, at most 1)"},
+		{"invalid", "red,gren", 2, nil, "invalid test: gren (expected a hex code, ANSI number or color name)"},
+		{"empty part", "red,", 2, nil, "invalid test:  (expected a hex code, ANSI number or color name)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseColors("test", tt.val, tt.limit)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestParsePadding(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -144,3 +507,245 @@ func TestCLIBigFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestCLIDuration(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantDuration string
+		wantLength   string
+		wantRangeSet bool
+	}{
+		{"duration flag", []string{"--duration", "5m"}, "5m", "", false},
+		{"positional argument", []string{"1h15m"}, "", "1h15m", false},
+		{"duration with range", []string{"--duration", "5m", "-r", "10..0"}, "5m", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cli CLI
+			parser, err := kong.New(&cli, kong.Name("countdown"))
+			require.NoError(t, err)
+
+			ctx, err := parser.Parse(tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDuration, cli.Duration)
+			assert.Equal(t, tt.wantLength, cli.Count.Length)
+			assert.Equal(t, tt.wantRangeSet, isFlagSet(ctx, "range"))
+		})
+	}
+}
+
+func TestCLICommands(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCmd    string
+		wantTitle  string
+		wantLength string
+	}{
+		{"default", []string{"5m"}, "count", "Liftoff in", "5m"},
+		{"count", []string{"count", "5m"}, "count", "Liftoff in", "5m"},
+		{"stopwatch", []string{"stopwatch"}, "stopwatch", "Liftoff in", ""},
+		{"flags before stopwatch", []string{"--title", "X", "stopwatch"}, "stopwatch", "X", ""},
+		{"flags after stopwatch", []string{"stopwatch", "--title", "X"}, "stopwatch", "X", ""},
+		{"config show", []string{"--title", "X", "config", "show"}, "show", "X", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cli CLI
+			parser, err := kong.New(&cli, kong.Name("countdown"))
+			require.NoError(t, err)
+
+			ctx, err := parser.Parse(tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCmd, ctx.Selected().Name)
+			assert.Equal(t, tt.wantTitle, cli.Title)
+			assert.Equal(t, tt.wantLength, cli.Count.Length)
+		})
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantArgs    []string
+		wantCommand []string
+	}{
+		{"no command", []string{"-r", "10..0"}, []string{"-r", "10..0"}, nil},
+		{"command", []string{"5m", "--", "make", "deploy"}, []string{"5m"}, []string{"make", "deploy"}},
+		{"only command", []string{"--", "true"}, []string{}, []string{"true"}},
+		{"second separator belongs to command", []string{"--", "git", "log", "--", "main.go"}, []string{}, []string{"git", "log", "--", "main.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, cmd := splitCommand(tt.args)
+			assert.Equal(t, tt.wantArgs, args)
+			assert.Equal(t, tt.wantCommand, cmd)
+		})
+	}
+}
+
+func TestSignalExitCode(t *testing.T) {
+	assert.Equal(t, 143, signalExitCode(syscall.SIGTERM))
+	assert.Equal(t, 130, signalExitCode(syscall.SIGINT))
+	assert.Equal(t, 130, signalExitCode(os.Interrupt))
+}
+
+func TestParseSequence(t *testing.T) {
+	dracula, _ := countdown.LookupTheme("dracula")
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []countdown.Segment
+		wantErr bool
+	}{
+		{"labels and durations", "Work:25m,Break:5m", []countdown.Segment{
+			{Label: "Work", Length: 1500},
+			{Label: "Break", Length: 300},
+		}, false},
+		{"with theme", "Work:25m:dracula, Rest:30s", []countdown.Segment{
+			{Label: "Work", Length: 1500, Theme: dracula},
+			{Label: "Rest", Length: 30},
+		}, false},
+		{"colon-style duration", "Sprint:01:30,Walk:02:00:dracula", []countdown.Segment{
+			{Label: "Sprint", Length: 90},
+			{Label: "Walk", Length: 120, Theme: dracula},
+		}, false},
+		{"bare duration", "45s", []countdown.Segment{{Length: 45}}, false},
+		{"missing duration", "Work", nil, true},
+		{"invalid duration", "Work:soon", nil, true},
+		{"zero duration", "Work:0s", nil, true},
+		{"unknown theme", "Work:25m:nonexistent", nil, true},
+		{"empty segment", "Work:25m,,Break:5m", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSequence(tt.input, time.Second, t.TempDir())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadAgenda(t *testing.T) {
+	now := time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := dir + "/agenda.yaml"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	path := write(`title: Track A
+items:
+  - title: Keynote
+    duration: 45m
+    stages: ["10%:red"]
+  - title: Coffee
+    until: "11:00"
+    theme: dracula
+  - title: Breakfast
+    until: "09:00"
+`)
+	limits := thresholds{finalPhase: "20%", stages: []string{"50%:yellow"}, scale: 1}
+	segments, title, err := loadAgenda(path, time.Second, dir, now, limits)
+	require.NoError(t, err)
+	assert.Equal(t, "Track A", title)
+	require.Len(t, segments, 3)
+
+	assert.Equal(t, "Keynote", segments[0].Label)
+	assert.Equal(t, 2700, segments[0].Length)
+	require.Len(t, segments[0].Stages, 1)
+	assert.Equal(t, 270, segments[0].Stages[0].Threshold, "Percentages should be of the item's length")
+	assert.Equal(t, 540, segments[0].FinalPhase)
+	assert.Nil(t, segments[0].Thresholds, "Items with a duration are as long as loaded")
+
+	assert.Equal(t, now.Add(time.Hour), segments[1].Deadline)
+	assert.Equal(t, 3600, segments[1].Length)
+	assert.Equal(t, "dracula", segments[1].Theme.Name)
+	require.Len(t, segments[1].Stages, 1, "Items without stages should use --stage")
+	require.NotNil(t, segments[1].Thresholds)
+	finalPhase, stages := segments[1].Thresholds(1000)
+	assert.Equal(t, 200, finalPhase, "Percentages should be of the time left when the item begins")
+	require.Len(t, stages, 1)
+	assert.Equal(t, 500, stages[0].Threshold)
+
+	assert.Equal(t, now.Add(-time.Hour), segments[2].Deadline, "A time which has passed today should be today")
+	assert.Equal(t, 0, segments[2].Length)
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"invalid duration", "- title: Talk\n  duration: soon\n", "agenda.yaml:2: invalid duration: soon"},
+		{"invalid until", "- title: Talk\n  duration: 5m\n- title: Lunch\n  until: noon\n", "agenda.yaml:4: invalid until value: noon"},
+		{"invalid stage", "- title: Talk\n  duration: 5m\n  stages:\n    - 10%:spinner=nope\n", "agenda.yaml:4: invalid stage"},
+		{"unknown theme", "- title: Talk\n  duration: 5m\n  theme: nonexistent\n", "agenda.yaml:3:"},
+		{"structure", "- title: Talk\n", "agenda.yaml:1: item \"Talk\" needs a duration or until time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := loadAgenda(write(tt.content), time.Second, dir, now, thresholds{finalPhase: "5", scale: 1})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestJSONOutputWithCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"wrapped", []string{"--output", "json", "-w", "1s", "--", "sh", "-c", "echo job-output; sleep 0.5"}},
+		{"run after", []string{"--output", "json", "1s", "--", "sh", "-c", "echo job-output"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := runMain(t, tt.args...)
+			require.NotEmpty(t, out)
+
+			scanner := bufio.NewScanner(strings.NewReader(out))
+			for scanner.Scan() {
+				var event countdown.Event
+				assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event), "Every line should be an event: %q", scanner.Text())
+			}
+		})
+	}
+}
+
+func TestEventsFile(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "events.json"))
+	require.NoError(t, err)
+	file := &eventsFile{File: f}
+	_, err = file.Write([]byte("{}\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	data, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "{}\n", string(data))
+
+	f, err = os.Open(f.Name())
+	require.NoError(t, err)
+	file = &eventsFile{File: f}
+	_, err = file.Write([]byte("{}\n"))
+	require.Error(t, err)
+	assert.Equal(t, err, file.Close(), "The first write error should be reported on closing")
+}